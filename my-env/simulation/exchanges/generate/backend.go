@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Backend emits the parsed and enriched ISO 10383 dataset in one target
+// format. All backends are driven off the same intermediate representation
+// main() builds: markets grouped by country (ms), the included/excluded
+// country code lists (ics, ecs), markets grouped by time zone (tzmics), and
+// markets grouped by trading currency (curmics).
+type Backend interface {
+	// Name is this backend's -target value, e.g. "py", "json" or "ts". It
+	// also becomes the generated file's extension.
+	Name() string
+	Emit(filename string, ms []*mic, ics, ecs []string, tzmics []*tzmic, curmics []*curmic)
+}
+
+// backends lists every Backend -target can select, in no particular order.
+var backends = []Backend{
+	pythonBackend{},
+	jsonBackend{},
+	typescriptBackend{},
+	goBackend{},
+	protobufBackend{},
+	micsPackedBackend{},
+}
+
+// resolveBackends parses a comma-separated -target value (e.g. "py,json")
+// into the Backends it names, in the order given.
+func resolveBackends(target string) ([]Backend, error) {
+	byName := make(map[string]Backend, len(backends))
+	for _, b := range backends {
+		byName[b.Name()] = b
+	}
+
+	names := strings.Split(target, ",")
+	selected := make([]Backend, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		b, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown -target %q, want one of py, json, ts, go, proto, micspkg", name)
+		}
+
+		selected = append(selected, b)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"go/format"
+)
+
+// goBackend emits ../micgo/mics.go: a MIC struct, one package-level var per
+// MIC, a code -> MIC lookup map, and ByMIC for runtime lookups - the
+// idiomatic shape for a small generated Go data package, avoiding
+// reflection or init-time parsing. It cannot write into generate/ itself
+// (package main, not an importable data package) and its per-MIC var shape
+// is a different, incompatible API from package mics' packed tables (see
+// backend_mics_packed.go), so it gets its own sibling package and directory
+// rather than colliding with either.
+type goBackend struct{}
+
+func (goBackend) Name() string { return "go" }
+
+func (goBackend) Emit(filename string, ms []*mic, ics, ecs []string, tzmics []*tzmic, curmics []*curmic) {
+	var b bytes.Buffer
+
+	printf(&b, "// Code generated by 'go generate'; DO NOT EDIT.\n\n")
+	printf(&b, "// Package micgo provides ISO 10383 Market Identifier Codes generated from\n")
+	printf(&b, "// data with publication date %v.\n", dataPublicationDate)
+	printf(&b, "package micgo\n\n")
+
+	printf(&b, "// MIC is an ISO 10383 Market Identifier Code and its associated metadata.\n")
+	printf(&b, "type MIC struct {\n")
+	printf(&b, "\tCode         string\n")
+	printf(&b, "\tOperatingMIC string\n")
+	printf(&b, "\tCountryCode  string\n")
+	printf(&b, "\tOperational  bool\n")
+	printf(&b, "\tName         string\n")
+	printf(&b, "\tCity         string\n")
+	printf(&b, "\tTimezone     string\n")
+	printf(&b, "\tStdOffset    int\n")
+	printf(&b, "\tDstOffset    int\n")
+	printf(&b, "\tCurrencies   []string\n")
+	printf(&b, "\tNames        map[string]string\n")
+	printf(&b, "}\n\n")
+
+	identifiers := make([]string, 0, len(ms))
+
+	for _, m := range ms {
+		printGoMarket(&b, m.oper)
+
+		identifiers = append(identifiers, safeMic(m.oper.mic))
+
+		for _, s := range m.segs {
+			printGoMarket(&b, s)
+
+			identifiers = append(identifiers, safeMic(s.mic))
+		}
+	}
+
+	printf(&b, "// micsByCode indexes every generated MIC by its code, for ByMIC.\n")
+	printf(&b, "var micsByCode = map[string]MIC{\n")
+
+	for _, id := range identifiers {
+		printf(&b, "\t%v.Code: %v,\n", id, id)
+	}
+
+	printf(&b, "}\n\n")
+
+	printf(&b, "// ByMIC looks up a MIC by its code, e.g. \"XNYS\".\n")
+	printf(&b, "func ByMIC(code string) (MIC, bool) {\n")
+	printf(&b, "\tm, ok := micsByCode[code]\n\n")
+	printf(&b, "\treturn m, ok\n")
+	printf(&b, "}\n")
+
+	printBuffer(&b, "../micgo/mics.go", format.Source)
+}
+
+func printGoMarket(b *bytes.Buffer, m *market) {
+	printf(b, "var %v = MIC{\n", safeMic(m.mic))
+	printf(b, "\tCode:         %q,\n", m.mic)
+	printf(b, "\tOperatingMIC: %q,\n", m.micOp)
+	printf(b, "\tCountryCode:  %q,\n", m.code)
+	printf(b, "\tOperational:  %v,\n", m.isOperational)
+	printf(b, "\tName:         %q,\n", m.name)
+	printf(b, "\tCity:         %q,\n", m.city)
+	printf(b, "\tTimezone:     %q,\n", m.tzname)
+	printf(b, "\tStdOffset:    %v,\n", m.stdOffset)
+	printf(b, "\tDstOffset:    %v,\n", m.dstOffset)
+	printf(b, "\tCurrencies:   %v,\n", goStringSlice(m.currencies))
+	printf(b, "\tNames:        %v,\n", goStringDict(m.names))
+	printf(b, "}\n\n")
+}
+
+// goStringDict renders names as a Go map literal ordered by nameLocales,
+// restricted to whichever locales names actually has.
+func goStringDict(names map[string]string) string {
+	var b bytes.Buffer
+
+	b.WriteString("map[string]string{")
+
+	first := true
+
+	for _, locale := range nameLocales {
+		v, ok := names[locale]
+		if !ok {
+			continue
+		}
+
+		if !first {
+			b.WriteString(", ")
+		}
+
+		first = false
+
+		printf(&b, "%q: %q", locale, v)
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
+
+func goStringSlice(ss []string) string {
+	var b bytes.Buffer
+
+	b.WriteString("[]string{")
+
+	for i, s := range ss {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		printf(&b, "%q", s)
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+const jsonSchema = "https://github.com/ishiyan/Proeftuin-py/schemas/mics.schema.json"
+
+const jsonSchemaVersion = 4
+
+// jsonDocument is the canonical, schema-versioned document emitted by
+// jsonBackend. Markets is sorted by MIC so the output is byte-stable across
+// runs regardless of the order enrichMarkets/arrangeByCountry produced.
+type jsonDocument struct {
+	Schema          string       `json:"$schema"`
+	SchemaVersion   int          `json:"schemaVersion"`
+	PublicationDate string       `json:"publicationDate"`
+	IncludedCountry []string     `json:"includedCountryCodes"`
+	ExcludedCountry []string     `json:"excludedCountryCodes"`
+	Markets         []jsonMarket `json:"markets"`
+}
+
+type jsonMarket struct {
+	MIC          string            `json:"mic"`
+	OperatingMIC string            `json:"operatingMic"`
+	CountryCode  string            `json:"countryCode"`
+	Operational  bool              `json:"operational"`
+	Name         string            `json:"name"`
+	City         string            `json:"city"`
+	Timezone     string            `json:"timezone"`
+	StdOffset    int               `json:"stdOffset"`
+	DstOffset    int               `json:"dstOffset"`
+	Currencies   []string          `json:"currencies"`
+	Names        map[string]string `json:"names"`
+}
+
+type jsonBackend struct{}
+
+func (jsonBackend) Name() string { return "json" }
+
+func (jsonBackend) Emit(filename string, ms []*mic, ics, ecs []string, tzmics []*tzmic, curmics []*curmic) {
+	doc := jsonDocument{
+		Schema:          jsonSchema,
+		SchemaVersion:   jsonSchemaVersion,
+		PublicationDate: dataPublicationDate,
+		IncludedCountry: ics,
+		ExcludedCountry: ecs,
+	}
+
+	for _, m := range ms {
+		doc.Markets = append(doc.Markets, toJSONMarket(m.oper))
+
+		for _, s := range m.segs {
+			doc.Markets = append(doc.Markets, toJSONMarket(s))
+		}
+	}
+
+	sort.Slice(doc.Markets, func(i, j int) bool { return doc.Markets[i].MIC < doc.Markets[j].MIC })
+
+	var b bytes.Buffer
+
+	enc := json.NewEncoder(&b)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(doc); err != nil {
+		die(err)
+	}
+
+	printBuffer(&b, filename, nil)
+}
+
+func toJSONMarket(m *market) jsonMarket {
+	return jsonMarket{
+		MIC:          m.mic,
+		OperatingMIC: m.micOp,
+		CountryCode:  m.code,
+		Operational:  m.isOperational,
+		Name:         m.name,
+		City:         m.city,
+		Timezone:     m.tzname,
+		StdOffset:    m.stdOffset,
+		DstOffset:    m.dstOffset,
+		Currencies:   m.currencies,
+		Names:        m.names,
+	}
+}
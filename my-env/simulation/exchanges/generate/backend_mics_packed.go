@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+)
+
+// micCodeLen is the fixed width, in bytes, of every MIC code packed into
+// micBlob; must match package mics' own micCodeLen constant.
+const micCodeLen = 4
+
+// packedTz carries the per-zone data micsPackedBackend needs in one
+// table entry: the IANA name plus its current standard/DST offsets.
+type packedTz struct {
+	name                 string
+	stdOffset, dstOffset int
+}
+
+// micsPackedBackend emits ../mics/data.go: the packed byte-blob tables
+// behind package mics' Lookup/ByCountry/ByTimezone/Segments/All API. Unlike
+// goBackend's one-var-per-MIC idiomatic output, this trades readability for
+// a compact, allocation-free runtime representation inspired by
+// golang.org/x/text/language's packed tables: every MIC code lives in one
+// sorted, fixed-width []byte blob, and country/timezone/operating-MIC
+// relationships are secondary index slices rather than per-entry structs.
+// It deliberately omits the per-MIC localized names map the other backends
+// carry (see city_names.go): baking a handful of locale strings per MIC into
+// this table would undercut the whole point of a compact, allocation-free
+// representation, so package mics has no localized-name lookup at all.
+type micsPackedBackend struct{}
+
+func (micsPackedBackend) Name() string { return "micspkg" }
+
+//nolint:funlen
+func (micsPackedBackend) Emit(filename string, ms []*mic, ics, ecs []string, tzmics []*tzmic, curmics []*curmic) {
+	rows := make([]*market, 0, len(ms))
+
+	for _, m := range ms {
+		rows = append(rows, m.oper)
+		rows = append(rows, m.segs...)
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].mic < rows[j].mic })
+
+	rowOf := make(map[string]int, len(rows))
+	for i, r := range rows {
+		rowOf[r.mic] = i
+	}
+
+	countryCodes := []string{}
+	countryIdx := map[string]int{}
+	countryIndex := make([]int, len(rows))
+
+	timeZones := []packedTz{}
+	tzIdx := map[string]int{}
+	tzIndex := make([]int, len(rows))
+
+	opIndex := make([]int, len(rows))
+
+	var blob bytes.Buffer
+
+	for i, r := range rows {
+		blob.WriteString(r.mic)
+
+		if _, ok := countryIdx[r.code]; !ok {
+			countryIdx[r.code] = len(countryCodes)
+			countryCodes = append(countryCodes, r.code)
+		}
+
+		countryIndex[i] = countryIdx[r.code]
+
+		if _, ok := tzIdx[r.tzname]; !ok {
+			tzIdx[r.tzname] = len(timeZones)
+			timeZones = append(timeZones, packedTz{r.tzname, r.stdOffset, r.dstOffset})
+		}
+
+		tzIndex[i] = tzIdx[r.tzname]
+
+		opIndex[i] = rowOf[r.micOp]
+	}
+
+	var b bytes.Buffer
+
+	printf(&b, "// Code generated by 'go generate'; DO NOT EDIT.\n\n")
+	printf(&b, "package mics\n\n")
+
+	printf(&b, "// micBlob packs every MIC code, %v bytes each, sorted ascending so rowOf\n", micCodeLen)
+	printf(&b, "// can binary-search it directly.\n")
+	printf(&b, "var micBlob = %q\n\n", blob.String())
+
+	printf(&b, "// countryCodes is the distinct ISO 3166 alpha-2 codes countryIndex points\n")
+	printf(&b, "// into, in first-seen order.\n")
+	printf(&b, "var countryCodes = %v\n\n", goStringSlice(countryCodes))
+
+	printf(&b, "// countryIndex[row] is the index into countryCodes for micBlob's row-th MIC.\n")
+	printf(&b, "var countryIndex = %v\n\n", goUint16Slice(countryIndex))
+
+	printf(&b, "// timeZones is the distinct IANA zones tzIndex points into, in first-seen\n")
+	printf(&b, "// order, alongside each zone's current standard and DST offsets.\n")
+	printf(&b, "var timeZones = []timeZone{\n")
+
+	for _, z := range timeZones {
+		printf(&b, "\t{%q, %v, %v},\n", z.name, z.stdOffset, z.dstOffset)
+	}
+
+	printf(&b, "}\n\n")
+
+	printf(&b, "// tzIndex[row] is the index into timeZones for micBlob's row-th MIC.\n")
+	printf(&b, "var tzIndex = %v\n\n", goUint16Slice(tzIndex))
+
+	printf(&b, "// opIndex[row] is the row of micBlob's row-th MIC's operating MIC (itself,\n")
+	printf(&b, "// for an operating MIC).\n")
+	printf(&b, "var opIndex = %v\n", goUint16Slice(opIndex))
+
+	printBuffer(&b, "../mics/data.go", format.Source)
+}
+
+func goUint16Slice(vals []int) string {
+	var b bytes.Buffer
+
+	b.WriteString("[]uint16{")
+
+	for i, v := range vals {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		fmt.Fprintf(&b, "%v", v)
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
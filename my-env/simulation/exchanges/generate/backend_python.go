@@ -0,0 +1,12 @@
+package main
+
+// pythonBackend emits mics.py, the generator's original and still default
+// target. Its logic lives in printMicsPython, which predates the Backend
+// interface.
+type pythonBackend struct{}
+
+func (pythonBackend) Name() string { return "py" }
+
+func (pythonBackend) Emit(filename string, ms []*mic, ics, ecs []string, tzmics []*tzmic, curmics []*curmic) {
+	printMicsPython(filename, ms, ics, ecs, tzmics, curmics)
+}
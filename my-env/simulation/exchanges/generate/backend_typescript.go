@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+)
+
+// typescriptBackend emits mics.ts: a `const mics = {...} as const` object
+// plus union types for every MIC, country code, operating MIC and IANA
+// zone, so downstream TS/JS trading tooling can consume the dataset without
+// re-parsing ISO CSVs.
+type typescriptBackend struct{}
+
+func (typescriptBackend) Name() string { return "ts" }
+
+//nolint:funlen
+func (typescriptBackend) Emit(filename string, ms []*mic, ics, ecs []string, tzmics []*tzmic, curmics []*curmic) {
+	var b bytes.Buffer
+
+	printf(&b, "// Code generated by 'go generate'; DO NOT EDIT.\n\n")
+	printf(&b, "// ISO 10383 Market Identifier Codes, data publication date %v.\n", dataPublicationDate)
+	printf(&b, "// https://www.iso20022.org/market-identifier-codes\n\n")
+
+	countryCodes := map[string]bool{}
+	operatingMics := map[string]bool{}
+	zones := map[string]bool{}
+	currencies := map[string]bool{}
+
+	printf(&b, "export const mics = {\n")
+
+	for _, m := range ms {
+		printTSMarket(&b, m.oper, countryCodes, operatingMics, zones, currencies)
+
+		for _, s := range m.segs {
+			printTSMarket(&b, s, countryCodes, operatingMics, zones, currencies)
+		}
+	}
+
+	printf(&b, "} as const;\n\n")
+
+	printf(&b, "export type MIC = keyof typeof mics;\n")
+	printf(&b, "export type CountryCode = %v;\n", tsUnion(sortedKeys(countryCodes)))
+	printf(&b, "export type OperatingMIC = %v;\n", tsUnion(sortedKeys(operatingMics)))
+	printf(&b, "export type TimeZone = %v;\n", tsUnion(sortedKeys(zones)))
+	printf(&b, "export type Currency = %v;\n", tsUnion(sortedKeys(currencies)))
+
+	printBuffer(&b, filename, nil)
+}
+
+func printTSMarket(b *bytes.Buffer, m *market, countryCodes, operatingMics, zones, currencies map[string]bool) {
+	printf(b,
+		"  %v: { mic: %q, operatingMic: %q, countryCode: %q, operational: %v, name: %q, city: %q, timezone: %q, "+
+			"stdOffset: %v, dstOffset: %v, currencies: %v, names: %v },\n",
+		safeMic(m.mic), m.mic, m.micOp, m.code, m.isOperational, m.name, m.city, m.tzname,
+		m.stdOffset, m.dstOffset, tsStringArray(m.currencies), tsStringDict(m.names))
+
+	countryCodes[m.code] = true
+	operatingMics[m.micOp] = true
+	zones[m.tzname] = true
+
+	for _, cur := range m.currencies {
+		currencies[cur] = true
+	}
+}
+
+func tsStringArray(ss []string) string {
+	var b bytes.Buffer
+
+	b.WriteString("[")
+
+	for i, s := range ss {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		printf(&b, "%q", s)
+	}
+
+	b.WriteString("]")
+
+	return b.String()
+}
+
+// tsStringDict renders names as a TS object literal ordered by nameLocales,
+// restricted to whichever locales names actually has.
+func tsStringDict(names map[string]string) string {
+	var b bytes.Buffer
+
+	b.WriteString("{ ")
+
+	first := true
+
+	for _, locale := range nameLocales {
+		v, ok := names[locale]
+		if !ok {
+			continue
+		}
+
+		if !first {
+			b.WriteString(", ")
+		}
+
+		first = false
+
+		printf(&b, "%q: %q", locale, v)
+	}
+
+	b.WriteString(" }")
+
+	return b.String()
+}
+
+func sortedKeys(m map[string]bool) []string {
+	ks := make([]string, 0, len(m))
+	for k := range m {
+		ks = append(ks, k)
+	}
+
+	sort.Strings(ks)
+
+	return ks
+}
+
+func tsUnion(ss []string) string {
+	var b bytes.Buffer
+
+	for i, s := range ss {
+		if i > 0 {
+			b.WriteString(" | ")
+		}
+
+		printf(&b, "%q", s)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+// cityCoord is a city's approximate coordinates, used to build the spatial
+// index printSpatialPython emits.
+type cityCoord struct {
+	lat, lon float64
+}
+
+func readCities(filename string) (map[string]cityCoord, error) {
+	ccm := map[string]cityCoord{}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return ccm, fmt.Errorf("opening cities: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.Comment = '#'
+	r.FieldsPerRecord = 3
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if err != nil {
+			return ccm, fmt.Errorf("'%v' line %v: error reading file: %w", filename, ln, err)
+		}
+
+		lat, err := strconv.ParseFloat(record[1], 64)
+		if err != nil {
+			return ccm, fmt.Errorf("'%v' line %v: invalid latitude: %w", filename, ln, err)
+		}
+
+		lon, err := strconv.ParseFloat(record[2], 64)
+		if err != nil {
+			return ccm, fmt.Errorf("'%v' line %v: invalid longitude: %w", filename, ln, err)
+		}
+
+		ccm[record[0]] = cityCoord{lat: lat, lon: lon}
+	}
+
+	return ccm, nil
+}
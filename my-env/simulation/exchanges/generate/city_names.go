@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// readCityNames reads city_names.csv, a curated overlay of localized display
+// names for cities whose correct name in one or more locales differs from
+// titleCaseCity's algorithmic fallback (diacritics, non-Latin scripts, or
+// casing conventions strings.Title gets wrong, e.g. "Tórshavn" or
+// "フランクフルト"). This repo has no CLDR/ICU dependency to derive these from,
+// so, like countries_ext.csv, it is hand-curated and only needs entries for
+// the cities that actually need correcting; everything else falls back to
+// titleCaseCity in every locale.
+func readCityNames(filename string) (map[string]map[string]string, error) {
+	cnm := map[string]map[string]string{}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return cnm, fmt.Errorf("opening city names: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.Comment = '#'
+	r.FieldsPerRecord = 1 + len(nameLocales)
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if err != nil {
+			return cnm, fmt.Errorf("'%v' line %v: error reading file: %w", filename, ln, err)
+		}
+
+		names := make(map[string]string, len(nameLocales))
+
+		for i, locale := range nameLocales {
+			if v := record[1+i]; v != "" {
+				names[locale] = v
+			}
+		}
+
+		cnm[record[0]] = names
+	}
+
+	return cnm, nil
+}
+
+// titleCaseCity is the algorithmic fallback for a city's display name when
+// city_names.csv has no (or no locale-specific) curated entry for it. It is
+// not CLDR-correct - it's the same title-casing the doc comments always used
+// - which is exactly why city_names.csv exists: to override it where it's
+// wrong.
+func titleCaseCity(city string) string {
+	//nolint:staticcheck // strings.Title is deprecated but there's no case-folding-aware replacement in std; city_names.csv overrides its mistakes.
+	return strings.Title(strings.ToLower(city))
+}
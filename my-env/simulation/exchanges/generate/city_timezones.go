@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// readCityTimezones reads ../mic/city_timezones.csv, the city -> IANA time
+// zone identifier table shared with package mic (mic/tz.go embeds the same
+// file). It used to be two independently hand-maintained Go map literals
+// that had already drifted apart; now there is exactly one copy to edit.
+func readCityTimezones(filename string) (map[string]string, error) {
+	czm := map[string]string{}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return czm, fmt.Errorf("opening city time zones: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if err != nil {
+			return czm, fmt.Errorf("'%v' line %v: error reading file: %w", filename, ln, err)
+		}
+
+		czm[record[0]] = record[1]
+	}
+
+	return czm, nil
+}
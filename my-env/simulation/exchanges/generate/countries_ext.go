@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// countryExtLocales lists the locales countries_ext.csv carries, in column
+// order after the continent/subregion columns.
+var countryExtLocales = []string{"en", "fr", "es", "de", "zh", "ja", "ru"}
+
+// countryExt carries country metadata beyond the ISO 3166 alpha-2 code and
+// English name readContries already provides: alpha-3/numeric-3 codes, the
+// primary ISO 4217 currency, international calling code, continent and
+// subregion, and a locale -> localized name table.
+type countryExt struct {
+	alpha3      string
+	numeric     string
+	currency    string
+	callingCode string
+	continent   string
+	subregion   string
+	names       map[string]string
+}
+
+var errUnknownCountryExt = errors.New("please add countries_ext.csv entry for country code")
+
+func readCountriesExt(filename string) (map[string]*countryExt, error) {
+	cem := map[string]*countryExt{}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return cem, fmt.Errorf("opening countries_ext: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.Comment = '#'
+	r.FieldsPerRecord = 7 + len(countryExtLocales)
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if err != nil {
+			return cem, fmt.Errorf("'%v' line %v: error reading file: %w", filename, ln, err)
+		}
+
+		names := make(map[string]string, len(countryExtLocales))
+		for i, locale := range countryExtLocales {
+			names[locale] = record[7+i]
+		}
+
+		cem[record[0]] = &countryExt{
+			alpha3:      record[1],
+			numeric:     record[2],
+			currency:    record[3],
+			callingCode: record[4],
+			continent:   record[5],
+			subregion:   record[6],
+			names:       names,
+		}
+	}
+
+	return cem, nil
+}
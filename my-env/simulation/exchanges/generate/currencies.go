@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// isoCurrencies is the compiled whitelist of ISO 4217 currency codes: every
+// active currency, plus "XXX" for "no currency" (used by the ZZ fallback
+// country). enrichMarkets rejects any market.currencies entry absent here.
+var isoCurrencies = map[string]bool{
+	"AED": true, "AFN": true, "ALL": true, "AMD": true, "ANG": true, "AOA": true,
+	"ARS": true, "AUD": true, "AWG": true, "AZN": true, "BAM": true, "BBD": true,
+	"BDT": true, "BGN": true, "BHD": true, "BIF": true, "BMD": true, "BND": true,
+	"BOB": true, "BRL": true, "BSD": true, "BTN": true, "BWP": true, "BYN": true,
+	"BZD": true, "CAD": true, "CDF": true, "CHF": true, "CLP": true, "CNY": true,
+	"COP": true, "CRC": true, "CUP": true, "CVE": true, "CZK": true, "DJF": true,
+	"DKK": true, "DOP": true, "DZD": true, "EGP": true, "ERN": true, "ETB": true,
+	"EUR": true, "FJD": true, "FKP": true, "GBP": true, "GEL": true, "GHS": true,
+	"GIP": true, "GMD": true, "GNF": true, "GTQ": true, "GYD": true, "HKD": true,
+	"HNL": true, "HTG": true, "HUF": true, "IDR": true, "ILS": true, "INR": true,
+	"IQD": true, "IRR": true, "ISK": true, "JMD": true, "JOD": true, "JPY": true,
+	"KES": true, "KGS": true, "KHR": true, "KMF": true, "KPW": true, "KRW": true,
+	"KWD": true, "KYD": true, "KZT": true, "LAK": true, "LBP": true, "LKR": true,
+	"LRD": true, "LSL": true, "LYD": true, "MAD": true, "MDL": true, "MGA": true,
+	"MKD": true, "MMK": true, "MNT": true, "MOP": true, "MRU": true, "MUR": true,
+	"MVR": true, "MWK": true, "MXN": true, "MYR": true, "MZN": true, "NAD": true,
+	"NGN": true, "NIO": true, "NOK": true, "NPR": true, "NZD": true, "OMR": true,
+	"PAB": true, "PEN": true, "PGK": true, "PHP": true, "PKR": true, "PLN": true,
+	"PYG": true, "QAR": true, "RON": true, "RSD": true, "RUB": true, "RWF": true,
+	"SAR": true, "SBD": true, "SCR": true, "SDG": true, "SEK": true, "SGD": true,
+	"SHP": true, "SLE": true, "SOS": true, "SRD": true, "SSP": true, "STN": true,
+	"SYP": true, "SZL": true, "THB": true, "TJS": true, "TMT": true, "TND": true,
+	"TOP": true, "TRY": true, "TTD": true, "TWD": true, "TZS": true, "UAH": true,
+	"UGX": true, "USD": true, "UYU": true, "UZS": true, "VES": true, "VND": true,
+	"VUV": true, "WST": true, "XAF": true, "XCD": true, "XOF": true, "XPF": true,
+	"XXX": true, "YER": true, "ZAR": true, "ZMW": true, "ZWL": true,
+}
+
+var errUnknownCurrency = errors.New("unknown ISO 4217 currency code")
+
+// readMicCurrencies reads the curated MIC -> currencies overlay: MICs whose
+// trading currency differs from (or adds to) their country's primary
+// currency, e.g. venues offering USD-denominated listings alongside their
+// home currency. A MIC absent here falls back to its country's currency.
+func readMicCurrencies(filename string) (map[string][]string, error) {
+	mcm := map[string][]string{}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		return mcm, fmt.Errorf("opening mic_currencies: %w", err)
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.Comma = '|'
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if err != nil {
+			return mcm, fmt.Errorf("'%v' line %v: error reading file: %w", filename, ln, err)
+		}
+
+		mcm[record[0]] = strings.Split(record[1], ",")
+	}
+
+	return mcm, nil
+}
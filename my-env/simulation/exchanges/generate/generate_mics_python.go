@@ -7,14 +7,16 @@ import (
 	"bytes"
 	"encoding/csv"
 	"errors"
+	"flag"
 	"fmt"
-	"go/format"
 	"io"
 	"os"
 	"sort"
 	"strings"
 	"time"
 	"unicode"
+
+	"github.com/ishiyan/Proeftuin-py/my-env/simulation/exchanges/sessions"
 )
 
 const dataPublicationDate = "10-May-2021"
@@ -34,33 +36,100 @@ type market struct {
 	status        string
 	creationDate  string
 	comments      string
-	tzsec         int // seconds east of UTC
+	tzname        string            // IANA time zone identifier, e.g. "Europe/Amsterdam"
+	stdOffset     int               // standard (non-DST) seconds east of UTC for tzname
+	dstOffset     int               // daylight-saving seconds east of UTC for tzname, equal to stdOffset if tzname has no DST
+	countryExt    *countryExt       // alpha-3/numeric codes, currency, continent, localized names
+	lat, lon      float64           // city coordinates, from cities.csv
+	currencies    []string          // ISO 4217 trading currencies, from mic_currencies.csv or countryExt.currency
+	names         map[string]string // locale -> localized city name, from city_names.csv or titleCaseCity
 }
 
 // Contains an operational MIC with its optional segment MICs.
 type mic struct {
-	oper *market   // operational MIC
-	segs []*market // segment MICs if any
+	oper *market             // operational MIC
+	segs []*market           // segment MICs if any
+	cal  *sessions.Calendar // trading-hours and holiday calendar, nil if sessions.yaml has none
 }
 
-// Contains a time zone offset in seconds and MICs having this offset.
+// Contains an IANA time zone identifier and the MICs observing it.
 type tzmic struct {
-	tzsec   int // seconds east of UTC
+	tzname  string // IANA time zone identifier, e.g. "Europe/Amsterdam"
 	markets []*market
 }
 
+// Contains an ISO 4217 currency code and the MICs trading in it. A market
+// trading in more than one currency appears under each of its currencies.
+type curmic struct {
+	currency string
+	markets  []*market
+}
+
 //nolint:misspell
 const cxanMic = "CXAN"
 
 var errUnknownTimezoneCity = errors.New("please add time zone for unknown city")
 
+var errUnloadableTimezone = errors.New("city maps to an IANA zone that Go cannot load")
+
+var errUnknownCityCoord = errors.New("please add cities.csv entry for unknown city")
+
+var targetFlag = flag.String("target", "py", "comma-separated list of backends to emit: py,json,ts,go,proto,micspkg")
+
+var localesFlag = flag.String(
+	"locales", strings.Join(nameLocales, ","),
+	"comma-separated list of locales to bake into each MIC's names map: "+strings.Join(nameLocales, ","),
+)
+
 func main() {
+	flag.Parse()
+
+	backends, err := resolveBackends(*targetFlag)
+	if err != nil {
+		die(err)
+	}
+
+	locales, err := resolveLocales(*localesFlag)
+	if err != nil {
+		die(err)
+	}
+
 	// selected country code slice and country code -> country name map
 	cs, cm, err := readContries("countries.csv")
 	if err != nil {
 		die(err)
 	}
 
+	// country code -> alpha-3/numeric codes, currency, continent, localized names
+	cem, err := readCountriesExt("countries_ext.csv")
+	if err != nil {
+		die(err)
+	}
+
+	// city -> latitude/longitude
+	ccm, err := readCities("cities.csv")
+	if err != nil {
+		die(err)
+	}
+
+	// MIC -> trading currencies, overriding the country's primary currency
+	mcm, err := readMicCurrencies("mic_currencies.csv")
+	if err != nil {
+		die(err)
+	}
+
+	// city -> locale -> localized display name, overriding titleCaseCity
+	cnm, err := readCityNames("city_names.csv")
+	if err != nil {
+		die(err)
+	}
+
+	// city -> IANA time zone identifier, shared with package mic
+	czm, err := readCityTimezones("../mic/city_timezones.csv")
+	if err != nil {
+		die(err)
+	}
+
 	// a slice of all market pointers
 	ms, err := readMarkets("ISO10383_MIC." + dataPublicationDate + ".csv")
 	if err != nil {
@@ -73,18 +142,33 @@ func main() {
 	// countries excluded from code generation
 	ecs := collectExcludedCountries(ms, cm)
 
-	// enrich with time zone seconds and more friendly country names
-	if err := enrichMarkets(ms, cm); err != nil {
+	// enrich with time zone, country names, extended country metadata, city coordinates, currencies, and localized city names
+	if err := enrichMarkets(ms, cm, cem, ccm, mcm, cnm, locales, czm); err != nil {
+		die(err)
+	}
+
+	// trading-hours and holiday calendars, keyed by operating MIC
+	cals, err := sessions.Load("sessions.yaml")
+	if err != nil {
+		die(err)
+	}
+
+	if err := sessions.ValidateOperatingMICs(cals, collectOperatingMICs(ms)); err != nil {
 		die(err)
 	}
 
 	// markets grouped by segments and arranged by country
-	ams := arrangeByCountry(ms, cs)
+	ams := arrangeByCountry(ms, cs, cals)
 
-	// markets grouped by seconds east of UTC
+	// markets grouped by IANA time zone identifier
 	tzmics := arrangeByTimeZone(ams)
 
-	printMicsPython("mics.py", ams, ics, ecs, tzmics)
+	// markets grouped by ISO 4217 trading currency
+	curmics := arrangeByCurrency(ams)
+
+	for _, b := range backends {
+		b.Emit("mics."+b.Name(), ams, ics, ecs, tzmics, curmics)
+	}
 }
 
 func readContries(filename string) ([]string, map[string]string, error) {
@@ -241,342 +325,104 @@ func parseMarket(record []string, ln int, filename string) (*market, error) {
 
 	return &market{
 		country, "", code, mic, micOp, os == "O", name, acronym, city, website,
-		statusDate, status, creationDate, comments, 0,
+		statusDate, status, creationDate, comments, "", 0, 0, nil, 0, 0, nil, nil,
 	}, nil
 }
 
 //nolint:gomnd,funlen
-func enrichMarkets(ms []*market, cm map[string]string) error {
-	// seconds east of UTC
-	tzm := map[string]int{
-		"":                          0,              // Unknown (GMT)
-		"AABENRAA":                  3600,           // Denmark (GMT+1)
-		"AALBORG":                   3600,           // Denmark (GMT+1)
-		"ABIDJAN":                   0,              // Côte d'Ivoire (GMT)
-		"ABU DHABI":                 3600 * 4,       // United Arab Emirates (GMT+4)
-		"ACCRA":                     0,              // Ghana (GMT)
-		"AHMEDABAD":                 3600*5 + 1800,  // Gujarat, India (GMT+5:30)
-		"ALGIERS":                   3600,           // Algeria (GMT+1)
-		"ALMA-ATA":                  3600 * 6,       // Kazakhstan (GMT+6)
-		"ALMATY":                    3600 * 6,       // Kazakhstan (GMT+6)
-		"AMMAN":                     3600 * 2,       // Jordan (GMT+2)
-		"AMSTERDAM":                 3600,           // The Netherlands (GMT+1)
-		"ANKARA":                    3600 * 3,       // Turkey (GMT+3)
-		"ANTANANARIVO":              3600 * 3,       // Madagascar (GMT+3)
-		"ASTANA":                    3600 * 6,       // Nur-Sultan, Kazakhstan (GMT+6)
-		"ASTI":                      3600,           // Italy (GMT+1)
-		"ASUNCION":                  3600 * -4,      // Paraguay (GMT-4)
-		"ATHENS":                    3600 * 2,       // Greece (GMT+2)
-		"ATLANTA":                   3600 * -5,      // Atlanta, GA, USA (GMT-5)
-		"AUCKLAND":                  3600 * 12,      // New Zealand (GMT+12)
-		"AYLESBURY":                 0,              // UK (GMT)
-		"BAGHDAD":                   3600 * 3,       // Iraq (GMT+3)
-		"BAKU":                      3600 * 4,       // Azerbaijan (GMT+4)
-		"BANGALORE":                 3600*5 + 1800,  // Karnataka, India (GMT+5:30)
-		"BANGKOK":                   3600 * 7,       // Thailand (GMT+7)
-		"BANJA LUKA":                3600,           // Bosnia and Herzegovina (GMT+1)
-		"BARCELONA":                 3600,           // Spain (GMT+1)
-		"BASSETERRE":                3600 * -4,      // Saint Kitts and Nevis (GMT-4)
-		"BEDMINSTER":                3600 * -5,      // NJ, USA (GMT-5)
-		"BEIJING":                   3600 * 8,       // China (GMT+8)
-		"BEIRUT":                    3600 * 2,       // Lebanon (GMT+2)
-		"BELGRADE":                  3600,           // Serbia (GMT+1)
-		"BERGEN":                    3600,           // Norway (GMT+1)
-		"BERLIN":                    3600,           // Germany (GMT+1)
-		"BERMUDA":                   3600 * -4,      // Bermuda (GMT-4)
-		"BERN":                      3600,           // Switzerland (GMT+1)
-		"BERNE":                     3600,           // Switzerland (GMT+1)
-		"BIELLA":                    3600,           // Province of Biella, Italy (GMT+1)
-		"BILBAO":                    3600,           // Spain (GMT+1)
-		"BISHKEK":                   3600 * 6,       // Kyrgyzstan (GMT+6)
-		"BLANTYRE":                  3600 * 2,       // Malawi (GMT+2)
-		"BOCA RATON":                3600 * -5,      // FL, USA (GMT-4)
-		"BOGOTA":                    3600 * -5,      // Colombia (GMT-5)
-		"BOLOGNA":                   3600,           // Italy (GMT+1)
-		"BOSTON":                    3600 * -5,      // MA, USA (GMT-5)
-		"BRATISLAVA":                3600,           // Slovakia (GMT+1)
-		"BRIDGETOWN":                3600 * -5,      // Barbados (GMT-4)
-		"BRUSSELS":                  3600,           // Belgium (GMT+1)
-		"BRYANSTON, SANDTON":        3600 * 2,       // Sandton, South Africa (GMT+2)
-		"BUCHAREST":                 3600 * 2,       // Romania (GMT+2)
-		"BUDAPEST":                  3600,           // Hungary (GMT+1)
-		"BUENOS AIRES":              3600 * -5,      // Argentina (GMT-3)
-		"CAIRO":                     3600 * 2,       // Egypt (GMT+2)
-		"CALCUTTA":                  3600*5 + 1800,  // West Bengal, India (GMT+5:30)
-		"CALGARY":                   3600 * -7,      // AB, Canada (GMT-7)
-		"CARACAS":                   3600 * -4,      // Capital District, Venezuela (GMT-4)
-		"CASABLANCA":                0,              // Morocco (GMT)
-		"CHARLOTTE":                 3600 * -5,      // NC, USA (GMT-5)
-		"CHATHAM":                   3600*12 + 2700, // Chatham Islands Territory, New Zealand (GMT+12:45)
-		"CHICAGO":                   3600 * -6,      // IL, USA (GMT-6)
-		"CHISINAU":                  3600 * 2,       // Moldova (GMT+2)
-		"CHITTAGONG":                3600 * 6,       // Bangladesh (GMT+6)
-		"CHIYODA-KU":                3600 * 9,       // Tokyo, Japan (GMT+9)
-		"CLUJ NAPOCA":               3600 * 2,       // Romania (GMT+2)
-		"COLOMBO":                   3600*5 + 1800,  // Sri Lanka (GMT+5:30)
-		"COPENHAGEN":                3600,           // Denmark (GMT+1)
-		"CORDOBA":                   3600 * -3,      // Argentina (GMT-3)
-		"CORRIENTES":                3600 * -3,      // Argentina (GMT-3)
-		"CYBERCITY, EBENE":          3600 * 4,       // Mauritius (GMT+4)
-		"DALIAN":                    3600 * 8,       // Liaoning, China (GMT+8)
-		"DAMASCUS":                  3600 * 2,       // Syria (GMT+2)
-		"DAR ES SALAAM":             3600 * 3,       // Tanzania (GMT+3)
-		"DELHI":                     3600*5 + 1800,  // India (GMT+5:30)
-		"DHAKA":                     3600 * 6,       // Bangladesh (GMT+6)
-		"DNIPROPETROVSK":            3600 * 2,       // Dnipropetrovsk Oblast, Ukraine (GMT+2)
-		"DOHA":                      3600 * 3,       // Qatar (GMT+3)
-		"DOUALA":                    3600,           // Cameroon (GMT+1)
-		"DUBAI":                     3600 * 4,       // United Arab Emirates (GMT+4)
-		"DUBLIN":                    0,              // County Dublin, Ireland (GMT)
-		"DUESSELDORF":               3600,           // Germany (GMT+1)
-		"EBENE":                     3600 * 4,       // Mauritius (GMT+4)
-		"EBENE CITY":                3600 * 4,       // Mauritius (GMT+4)
-		"EDEN ISLAND":               3600 * 4,       // Seychelles (GMT+4)
-		"EDINBURGH":                 0,              // UK (GMT)
-		"EL SALVADOR":               3600 * -6,      // El Salvador (GMT-6)
-		"ESCH-SUR-ALZETTE":          3600,           // Luxembourg (GMT+1)
-		"ESPIRITO SANTO":            3600 * -3,      // Brazil (GMT-3)
-		"ESPOO":                     3600 * 2,       // Finland (GMT+2)
-		"FIAC":                      3600,           // France (GMT+1)
-		"FIRENZE":                   3600,           // Italy (GMT+1)
-		"FLORENCE":                  3600,           // Italy (GMT+1)
-		"FRANKFURT":                 3600,           // Germany (GMT+1)
-		"FRANKFURT AM MAIN":         3600,           // Germany (GMT+1)
-		"FUKUOKA":                   3600 * 9,       // Japan (GMT+9)
-		"GABORONE":                  3600 * 2,       // Botswana (GMT+2)
-		"GANDHINAGAR":               3600*5 + 1800,  // Gujarat, India (GMT+5:30)
-		"GEORGETOWN":                3600 * -4,      // Guyana (GMT-4)
-		"GIBRALTAR":                 3600,           // Gibraltar (GMT+1)
-		"GIFT CITY, GANDHINAGAR":    3600*5 + 1800,  // Gujarat, India (GMT+5:30)
-		"GLENVIEW":                  3600 * -6,      // IL, USA (GMT-6)
-		"GREAT NECK":                3600 * -5,      // NY, USA (GMT-5)
-		"GREENWICH":                 0,              // UK (GMT)
-		"GRINDSTED":                 3600,           // Denmark (GMT+1)
-		"GUATEMALA":                 3600 * -6,      // Guatemala (GMT-6)
-		"GUAYAQUIL":                 3600 * -5,      // Ecuador (GMT-5)
-		"GUAYNABO":                  3600 * -4,      // Puerto Rico (GMT-4)
-		"GUILDFORD":                 0,              // UK (GMT)
-		"HAMBURG":                   3600,           // Germany (GMT+1)
-		"HAMILTON":                  3600 * -5,      // ON, Canada (GMT-5)
-		"HANNOVER":                  3600,           // Germany (GMT+1)
-		"HANOI":                     3600 * 7,       // Hoàn Kiếm, Hanoi, Vietnam (GMT+7)
-		"HARARE":                    3600 * 2,       // Zimbabwe (GMT+2)
-		"HELSINKI":                  3600 * 2,       // Finland (GMT+2)
-		"HO CHI MINH CITY":          3600 * 7,       // Vietnam (GMT+7)
-		"HONG KONG":                 3600 * 7,       // Hong Kong (GMT+8)
-		"HORSENS":                   3600,           // Denmark (GMT+1)
-		"HOVE":                      0,              // UK (GMT)
-		"INDORE MADHYA PRADESH":     3600*5 + 1800,  // Madhya Pradesh, India (GMT+5:30)
-		"ISTANBUL":                  3600 * 3,       // Turkey (GMT+3)
-		"JAKARTA":                   3600 * 7,       // Indonesia (GMT+7)
-		"JERSEY CITY":               3600 * -5,      // NJ, USA (GMT-5)
-		"JOHANNESBURG":              3600 * 2,       // South Africa (GMT+2)
-		"KAMPALA":                   3600 * 3,       // Uganda (GMT+3)
-		"KANSAS CITY":               3600 * -6,      // MO, USA (GMT-6)
-		"KARACHI":                   3600 * 5,       // Pakistan (GMT+5)
-		"KATHMANDU":                 3600*5 + 2700,  // Nepal (GMT+5:45)
-		"KHARKOV":                   3600 * 2,       // Kharkiv Oblast, Ukraine (GMT+2)
-		"KHARTOUM":                  3600 * 2,       // Sudan (GMT+2)
-		"KIEL":                      3600,           // Germany (GMT+1)
-		"KIEV":                      3600 * 2,       // Ukraine (GMT+2)
-		"KIGALI":                    3600 * 2,       // Rwanda (GMT+2)
-		"KINGSTON":                  3600 * -5,      // ON, Canada (GMT-5)
-		"KINGSTOWN":                 3600 * -4,      // Saint Vincent and the Grenadines (GMT-4)
-		"KLAGENFURT AM WOERTHERSEE": 3600,           // Austria (GMT+1)
-		"KONGSVINGER":               3600,           // Norway (GMT+1)
-		"KUALA LUMPUR":              3600 * 8,       // Federal Territory of Kuala Lumpur, Malaysia (GMT+8)
-		"KUWAIT":                    3600 * 3,       // Kuwait (GMT+3)
-		"KYIV":                      3600 * 2,       // Ukraine (GMT+2)
-		"LA PAZ":                    3600 * -4,      // Bolivia (GMT-4)
-		"LABUAN":                    3600 * 8,       // Labuan Federal Territory, Malaysia (GMT+8)
-		"LAGOS":                     3600,           // Nigeria (GMT+1)
-		"LANE COVE":                 3600 * 10,      // NSW, Australia (GMT+10)
-		"LAO":                       3600 * 7,       // Laos (GMT+7)
-		"LARNACA":                   3600 * 2,       // Cyprus (GMT+2)
-		"LEIPZIG":                   3600,           // Germany (GMT+1)
-		"LIMA":                      3600 * -5,      // Peru (GMT-5)
-		"LIMASSOL":                  3600 * 2,       // Cyprus (GMT+2)
-		"LINZ":                      3600,           // Austria (GMT+1)
-		"LISBOA":                    0,              // Portugal (GMT)
-		"LISBON":                    0,              // Portugal (GMT)
-		"LJUBLJANA":                 3600,           // Slovenia (GMT+1)
-		"LONDON":                    0,              // UK (GMT)
-		"LUANDA":                    3600,           // Angola (GMT+1)
-		"LUSAKA":                    3600 * 2,       // Zambia (GMT+2)
-		"LUXEMBOURG":                3600,           // Luxembourg (GMT+1)
-		"LUZERN":                    3600,           // Switzerland (GMT+1)
-		"MADRAS":                    3600*5 + 1800,  // Tamil Nadu, India (GMT+5:30)
-		"MADRID":                    3600,           // Spain (GMT+1)
-		"MAKATI CITY":               3600 * 8,       // Metro Manila, Philippines (GMT+8)
-		"MALE":                      3600 * 5,       // Maldives (GMT+5)
-		"MANAGUA":                   3600 * -6,      // Nicaragua (GMT-6)
-		"MANAMA":                    3600 * 3,       // Bahrain (GMT+3)
-		"MANILA":                    3600 * 8,       // Metro Manila, Philippines (GMT+8)
-		"MAPUTO":                    3600 * 2,       // Mozambique (GMT+2)
-		"MARINGA":                   3600 * -3,      // Maringá - State of Paraná, Brazil (GMT-3)
-		"MBABANE":                   3600 * 2,       // Eswatini (GMT+2)
-		"MELBOURNE":                 3600 * 10,      // Melbourne VIC, Australia (GMT+10)
-		"MENDOZA":                   3600 * -3,      // Mendoza Province, Argentina (GMT-3)
-		"MEXICO":                    3600 * -6,      // CDMX, Mexico (GMT-6)
-		"MILAN":                     3600,           // Italy (GMT+1)
-		"MILANO":                    3600,           // Italy (GMT+1)
-		"MINNEAPOLIS":               3600 * -6,      // MN, USA (GMT-6)
-		"MINSK":                     3600 * 3,       // Belarus (GMT+3)
-		"MONTENEGRO":                3600,           // Montenegro (GMT+1)
-		"MONTEVIDEO":                3600 * -3,      // Montevideo Department, Uruguay (GMT-3)
-		"MONTREAL":                  3600 * -5,      // QC, Canada (GMT-5)
-		"MOORPARK":                  3600 * -8,      // CA, USA (GMT-8)
-		"MOSCOW":                    3600 * 3,       // Russia (GMT+3)
-		"MOUNT PLEASANT":            3600 * -5,      // MI, USA (GMT-5)
-		"MUENCHEN":                  3600,           // Germany (GMT+1)
-		"MUMBAI":                    3600*5 + 1800,  // Maharashtra, India (GMT+5:30)
-		"MUNICH":                    3600,           // Germany (GMT+1)
-		"MUSCAT":                    3600 * 4,       // Oman (GMT+4)
-		"NABLUS":                    3600 * 2,       // Nablus (GMT+2)
-		"NACKA":                     3600,           // Sweden (GMT+1)
-		"NAGOYA":                    3600 * 9,       // Aichi, Japan (GMT+9)
-		"NAIROBI":                   3600 * 3,       // Kenya (GMT+3)
-		"NARBERTH":                  3600 * -5,      // PA, USA (GMT-5)
-		"NASAU":                     3600 * -5,      // The Bahamas (GMT-5)
-		"NEW JERSEY":                3600 * -5,      // USA (GMT-5)
-		"NEW YORK":                  3600 * -5,      // NY, USA (GMT-5)
-		"NEWCASTLE":                 0,              // UK (GMT)
-		"NICOSIA":                   3600 * 2,       // Cyprus (GMT+2)
-		"NICOSIA (LEFKOSIA)":        3600 * 2,       // Cyprus (GMT+2)
-		"NORTH BERGEN":              3600 * -5,      // NJ, USA (GMT-5)
-		"NOVOSIBIRSK":               3600 * 7,       // Novosibirsk Oblast, Russia (GMT+7)
-		"NYON":                      3600,           // Switzerland (GMT+1)
-		"ODESSA":                    3600 * 2,       // Odessa Oblast, Ukraine (GMT+2)
-		"OLDENBURG":                 3600,           // Germany (GMT+1)
-		"OSAKA":                     3600 * 9,       // Japan (GMT+9)
-		"OSLO":                      3600,           // Norway (GMT+1)
-		"PADOVA":                    3600,           // Province of Padua, Italy (GMT+1)
-		"PALMA DE MALLORCA":         3600,           // Spain (GMT+1)
-		"PANAMA":                    3600 * -5,      // Panama (GMT-5)
-		"PARIS":                     3600,           // France (GMT+1)
-		"PASIG CITY":                3600 * 8,       // Metro Manila, Philippines (GMT+8)
-		"PHILADELPHIA":              3600 * -5,      // PA, USA (GMT-5)
-		"PHNOM PENH":                3600 * 7,       // Cambodia (GMT+7)
-		"PORT LOUIS":                3600 * 4,       // Mauritius (GMT+4)
-		"PORT MORESBY":              3600 * 10,      // Papua New Guinea (GMT+10)
-		"PORT OF SPAIN":             3600 * -4,      // Trinidad and Tobago (GMT-4)
-		"PORT VILA":                 3600 * 11,      // Vanuatu (GMT+11)
-		"PRAGUE":                    3600,           // Czechia (GMT+1)
-		"PRAIA":                     3600 * -1,      // Cape Verde (GMT-1)
-		"PRINCETON":                 3600 * -5,      // NJ, USA (GMT-5)
-		"PURCHASE":                  3600 * -5,      // Harrison, NY, USA (GMT-5)
-		"QUITO":                     3600 * -5,      // Ecuador (GMT-5)
-		"RANDERS":                   3600,           // Denmark (GMT+1)
-		"RED BANK":                  3600 * -5,      // NJ, USA (GMT-5)
-		"REGENSBURG":                3600,           // Germany (GMT+1)
-		"REGGIO EMILIA":             3600,           // Province of Reggio Emilia, Italy (GMT+1)
-		"REYKJAVIK":                 0,              // Iceland (GMT)
-		"RIGA":                      3600 * 2,       // Rīgas pilsēta, Latvia (GMT+2)
-		"RIO DE JANEIRO":            3600 * -3,      // State of Rio de Janeiro, Brazil (GMT-3)
-		"RIYADH":                    3600 * 3,       // Saudi Arabia (GMT+3)
-		"RODGAU":                    3600,           // Germany (GMT+1)
-		"ROMA":                      3600,           // Italy (GMT+1)
-		"ROME":                      3600,           // Italy (GMT+1)
-		"ROSARIO":                   3600 * -3,      // Santa Fe Province, Argentina (GMT-3)
-		"S-HERTOGENBOSCH":           3600,           // The Netherlands (GMT+1)
-		"SABADELL":                  3600,           // Spain (GMT+1)
-		"SAINT-PETERSBURG":          3600 * 3,       // Russia (GMT+3)
-		"SALZBURG":                  3600,           // Austria (GMT+1)
-		"SAMARA":                    3600 * 4,       // Samara Oblast, Russia (GMT+4)
-		"SAN CARLOS":                3600 * -8,      // CA, USA (GMT-8)
-		"SAN FRANCISCO":             3600 * -8,      // CA, USA (GMT-8)
-		"SAN JOSE":                  3600 * -8,      // CA, USA (GMT-8)
-		"SANTA FE":                  3600 * -7,      // NM, USA (GMT-7)
-		"SANTANDER":                 3600,           // Spain (GMT+1)
-		"SANTIAGO":                  3600 * -4,      // Chile (GMT-4)
-		"SANTO DOMINGO":             3600 * -4,      // Dominican Republic (GMT-4)
-		"SAO PAULO":                 3600 * -3,      // State of São Paulo, Brazil (GMT-3)
-		"SAPPORO":                   3600 * 9,       // Hokkaido, Japan (GMT+9)
-		"SARAJEVO":                  3600,           // Bosnia and Herzegovina (GMT+1)
-		"SEOUL":                     3600 * 9,       // South Korea (GMT+9)
-		"SHANGHAI":                  3600 * 8,       // China (GMT+8)
-		"SHENZHEN":                  3600 * 8,       // Guangdong Province, China (GMT+8)
-		"SILKEBORG":                 3600,           // Denmark (GMT+1)
-		"SINGAPORE":                 3600 * 8,       // Singapore (GMT+8)
-		"SKOPJE":                    3600,           // North Macedonia (GMT+1)
-		"SLIEMA":                    3600,           // Malta (GMT+1)
-		"SOFIA":                     3600 * 2,       // Bulgaria (GMT+2)
-		"SPLIT":                     3600,           // Croatia (GMT+1)
-		"ST ALBANS":                 0,              // UK (GMT)
-		"ST.  PETER PORT":           0,              // Guernsey (GMT)
-		"STAMFORD":                  3600 * -5,      // CT, USA (GMT-5)
-		"STOCKHOLM":                 3600,           // Sweden (GMT+1)
-		"STUTTGART":                 3600,           // Germany (GMT+1)
-		"SUVA":                      3600 * 12,      // Fiji (GMT+12)
-		"SYDNEY":                    3600 * 10,      // NSW, Australia (GMT+10)
-		"TAIPEI":                    3600 * 8,       // Taiwan (GMT+8)
-		"TAIWAN":                    3600 * 8,       // Taiwan (GMT+8)
-		"TALLINN":                   3600 * 2,       // Harju County, Estonia (GMT+2)
-		"TASHKENT":                  3600 * 5,       // Uzbekistan (GMT+5)
-		"TBILISI":                   3600 * 4,       // Georgia (GMT+4)
-		"TEGUCIGALPA":               3600 * -6,      // Honduras (GMT-6)
-		"TEHRAN":                    3600*3 + 1800,  // Iran (GMT+3:30)
-		"TEL AVIV":                  3600 * 2,       // Yafo, Israel (GMT+2)
-		"THE HAGUE":                 3600,           // The Netjerlands (GMT+1)
-		"TIRANA":                    3600,           // Albania (GMT+1)
-		"TOKYO":                     3600 * 9,       // Japan (GMT+9)
-		"TORINO":                    3600,           // Italy (GMT+1)
-		"TORONTO":                   3600 * -5,      // ON, Canada (GMT-5)
-		"TORSHAVN":                  0,              // Faroe Islands (GMT)
-		"TRIPOLI":                   3600 * 2,       // Libya (GMT+2)
-		"TROMSO":                    3600,           // Norway (GMT+1)
-		"TRONDHEIM":                 3600,           // Norway (GMT+1)
-		"TUCUMAN":                   3600 * -3,      // Tucumán, Argentina (GMT-3)
-		"TUNIS":                     3600,           // Tunisia (GMT+1)
-		"ULAAN BAATAR":              3600 * 8,       // Mongolia (GMT+8)
-		"UNTERSCHLEISSHEM":          3600,           // Germany (GMT+1)
-		"UTRECHT":                   3600,           // The Netherlands (GMT+1)
-		"VADUZ":                     3600,           // Liechtenstein (GMT+1)
-		"VALENCIA":                  3600,           // Spain (GMT+1)
-		"VALLETTA":                  3600,           // Malta (GMT+1)
-		"VALPARAISO":                3600 * -6,      // IN, USA (GMT-6)
-		"VANCOUVER":                 3600 * -8,      // BC, Canada (GMT-8)
-		"VICTORIA":                  3600 * 10,      // Australia (GMT+10)
-		"VIENNA":                    3600,           // Austria (GMT+1)
-		"VILA":                      3600 * 11,      // Vanuatu (GMT+11)
-		"VILNIUS":                   3600 * 2,       // Lithuania (GMT+2)
-		"WARSAW":                    3600,           // Poland (GMT+1)
-		"WARSZAWA":                  3600,           // Poland (GMT+1)
-		"WASHINGTON":                3600 * -8,      // USA (GMT-8)
-		"WASHINGTON/NEW YORK":       3600 * -5,      // NY, USA (GMT-5)
-		"WELLINGTON":                3600 * 12,      // New Zealand (GMT+12)
-		"WILLEMSTAD":                3600 * -4,      // Curaçao (GMT-4)
-		"WILMINGTON":                3600 * -5,      // DE, USA (GMT-5)
-		"WINDHOEK":                  3600 * 2,       // Namibia (GMT+2)
-		"WINNIPEG":                  3600 * -6,      // MB, Canada (GMT-6)
-		"WROCLAW":                   3600,           // Poland (GMT+1)
-		"WUXI":                      3600 * 8,       // Jiangsu, China (GMT+8)
-		"YEREVAN":                   3600 * 4,       // Armenia (GMT+4)
-		"ZAGREB":                    3600,           // Croatia (GMT+1)
-		"ZARAGOZA":                  3600,           // Spain (GMT+1)
-		"ZHENGZHOU":                 3600 * 8,       // Henan, China (GMT+8)
-		"ZILINA":                    3600,           // Slovakia (GMT+1)
-		"ZURICH":                    3600,           // Switzerland (GMT+1)
-		"ZZ":                        0,              // Unknown (GMT)
-	}
+func enrichMarkets(
+	ms []*market, cm map[string]string, cem map[string]*countryExt, ccm map[string]cityCoord,
+	mcm map[string][]string, cnm map[string]map[string]string, locales []string, czm map[string]string,
+) error {
+	zones := map[string]*time.Location{}
 
 	for _, m := range ms {
 		if v, ok := cm[m.code]; ok {
 			m.countryInc = v
 		}
 
-		t, ok := tzm[m.city]
+		ext, ok := cem[m.code]
+		if !ok {
+			return fmt.Errorf("'%v': %w", m.code, errUnknownCountryExt)
+		}
+
+		m.countryExt = ext
+
+		z, ok := czm[m.city]
 		if !ok {
 			return fmt.Errorf("'%v': %w", m.city, errUnknownTimezoneCity)
 		}
 
-		m.tzsec = t
+		loc, ok := zones[z]
+		if !ok {
+			var err error
+
+			loc, err = time.LoadLocation(z)
+			if err != nil {
+				return fmt.Errorf("'%v' -> '%v': %w: %v", m.city, z, errUnloadableTimezone, err)
+			}
+
+			zones[z] = loc
+		}
+
+		m.tzname = z
+		m.stdOffset, m.dstOffset = stdDstOffsets(loc)
+
+		coord, ok := ccm[m.city]
+		if !ok {
+			return fmt.Errorf("'%v': %w", m.city, errUnknownCityCoord)
+		}
+
+		m.lat, m.lon = coord.lat, coord.lon
+
+		currencies, ok := mcm[m.mic]
+		if !ok {
+			currencies = []string{ext.currency}
+		}
+
+		for _, cur := range currencies {
+			if !isoCurrencies[cur] {
+				return fmt.Errorf("'%v' -> '%v': %w", m.mic, cur, errUnknownCurrency)
+			}
+		}
+
+		m.currencies = currencies
+
+		fallback := titleCaseCity(m.city)
+		names := make(map[string]string, len(locales))
+
+		for _, locale := range locales {
+			if v, ok := cnm[m.city][locale]; ok {
+				names[locale] = v
+			} else {
+				names[locale] = fallback
+			}
+		}
+
+		m.names = names
 	}
 
 	return nil
 }
 
-func arrangeByCountry(ms []*market, cs []string) []*mic {
+// stdDstOffsets samples loc's UTC offset in January and July of the current
+// year and returns (standard, daylight-saving) seconds east of UTC, lowest
+// first. This works regardless of hemisphere: a zone with no DST returns the
+// same value twice.
+func stdDstOffsets(loc *time.Location) (int, int) {
+	year := time.Now().Year()
+
+	_, jan := time.Date(year, time.January, 1, 12, 0, 0, 0, loc).Zone()
+	_, jul := time.Date(year, time.July, 1, 12, 0, 0, 0, loc).Zone()
+
+	if jan <= jul {
+		return jan, jul
+	}
+
+	return jul, jan
+}
+
+func arrangeByCountry(ms []*market, cs []string, cals map[string]*sessions.Calendar) []*mic {
 	ams := []*mic{}
 
 	// colllect operational MICs odered by country
@@ -592,7 +438,7 @@ func arrangeByCountry(ms []*market, cs []string) []*mic {
 					}
 				}
 
-				ams = append(ams, &mic{m, ss})
+				ams = append(ams, &mic{m, ss, cals[m.micOp]})
 			}
 		}
 	}
@@ -600,24 +446,24 @@ func arrangeByCountry(ms []*market, cs []string) []*mic {
 	return ams
 }
 
-func collectTimeZones(ms []*mic) (map[int][]*market, []int) {
-	tzm := map[int][]*market{}
-	tzs := []int{}
+func collectTimeZones(ms []*mic) (map[string][]*market, []string) {
+	tzm := map[string][]*market{}
+	tzs := []string{}
 
 	for _, m := range ms {
-		if v, ok := tzm[m.oper.tzsec]; ok {
-			tzm[m.oper.tzsec] = append(v, m.oper)
+		if v, ok := tzm[m.oper.tzname]; ok {
+			tzm[m.oper.tzname] = append(v, m.oper)
 		} else {
-			tzm[m.oper.tzsec] = []*market{m.oper}
-			tzs = append(tzs, m.oper.tzsec)
+			tzm[m.oper.tzname] = []*market{m.oper}
+			tzs = append(tzs, m.oper.tzname)
 		}
 
 		for _, s := range m.segs {
-			if v, ok := tzm[s.tzsec]; ok {
-				tzm[s.tzsec] = append(v, s)
+			if v, ok := tzm[s.tzname]; ok {
+				tzm[s.tzname] = append(v, s)
 			} else {
-				tzm[s.tzsec] = []*market{s}
-				tzs = append(tzs, s.tzsec)
+				tzm[s.tzname] = []*market{s}
+				tzs = append(tzs, s.tzname)
 			}
 		}
 	}
@@ -627,7 +473,7 @@ func collectTimeZones(ms []*mic) (map[int][]*market, []int) {
 
 func arrangeByTimeZone(ms []*mic) []*tzmic {
 	tzm, tzs := collectTimeZones(ms)
-	sort.Ints(tzs)
+	sort.Strings(tzs)
 
 	tzmics := make([]*tzmic, 0, len(tzs))
 	for _, t := range tzs {
@@ -637,6 +483,44 @@ func arrangeByTimeZone(ms []*mic) []*tzmic {
 	return tzmics
 }
 
+func collectCurrencies(ms []*mic) (map[string][]*market, []string) {
+	cm := map[string][]*market{}
+	cs := []string{}
+
+	add := func(m *market) {
+		for _, cur := range m.currencies {
+			if v, ok := cm[cur]; ok {
+				cm[cur] = append(v, m)
+			} else {
+				cm[cur] = []*market{m}
+				cs = append(cs, cur)
+			}
+		}
+	}
+
+	for _, m := range ms {
+		add(m.oper)
+
+		for _, s := range m.segs {
+			add(s)
+		}
+	}
+
+	return cm, cs
+}
+
+func arrangeByCurrency(ms []*mic) []*curmic {
+	cm, cs := collectCurrencies(ms)
+	sort.Strings(cs)
+
+	curmics := make([]*curmic, 0, len(cs))
+	for _, c := range cs {
+		curmics = append(curmics, &curmic{c, cm[c]})
+	}
+
+	return curmics
+}
+
 func collectExcludedCountries(ms []*market, icm map[string]string) []string {
 	type void struct{}
 
@@ -683,6 +567,18 @@ func collectIncludedCountries(ms []*market, icm map[string]string) []string {
 	return ncs
 }
 
+// collectOperatingMICs returns the set of operating MICs present in ms, so
+// sessions.yaml can be validated against it.
+func collectOperatingMICs(ms []*market) map[string]bool {
+	oms := map[string]bool{}
+
+	for _, m := range ms {
+		oms[m.micOp] = true
+	}
+
+	return oms
+}
+
 func die(err error) {
 	fmt.Println(err) //nolint:forbidigo
 	os.Exit(1)
@@ -742,9 +638,66 @@ func concatenateMics(ms []*market) string {
 	return b.String()
 }
 
+// pyStringTuple renders ss as a Python tuple literal of quoted strings, e.g.
+// ('EUR', 'USD'). A single element gets a trailing comma, per Python's tuple
+// syntax.
+func pyStringTuple(ss []string) string {
+	var b bytes.Buffer
+
+	b.WriteString("(")
+
+	for i, s := range ss {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		printf(&b, "'%v'", s)
+	}
+
+	if len(ss) == 1 {
+		b.WriteString(",")
+	}
+
+	b.WriteString(")")
+
+	return b.String()
+}
+
+// pyStringDict renders names as a Python dict literal ordered by
+// nameLocales, restricted to whichever locales names actually has - so a
+// -locales subset stays a subset in the generated output too.
+func pyStringDict(names map[string]string) string {
+	var b bytes.Buffer
+
+	b.WriteString("{")
+
+	first := true
+
+	for _, locale := range nameLocales {
+		v, ok := names[locale]
+		if !ok {
+			continue
+		}
+
+		if !first {
+			b.WriteString(", ")
+		}
+
+		first = false
+
+		printf(&b, "'%v': '%v'", locale, v)
+	}
+
+	b.WriteString("}")
+
+	return b.String()
+}
+
 //nolint:misspell,cyclop
 func printMicPython(w io.Writer, m *market) {
-	printf(w, "    %v = MIC('%v', '%v', '%v', %v)\n", safeMic(m.mic), m.mic, m.micOp, m.code, m.tzsec)
+	printf(w, "    %v = MIC('%v', '%v', '%v', tz='%v', std_offset=%v, dst_offset=%v, currencies=%v, names=%v)\n",
+		safeMic(m.mic), m.mic, m.micOp, m.code, m.tzname, m.stdOffset, m.dstOffset,
+		pyStringTuple(m.currencies), pyStringDict(m.names))
 
 	if m.isOperational {
 		printf(w, "    \"\"\"%v - operational", safeMic(m.mic))
@@ -772,7 +725,12 @@ func printMicPython(w io.Writer, m *market) {
 	}
 
 	if m.city != "" && m.city != "ZZ" {
-		s := strings.Title(strings.ToLower(m.city))
+		// Prefer the curated English name over titleCaseCity's algorithmic
+		// fallback, e.g. "Tórshavn" rather than "Torshavn".
+		s := m.names["en"]
+		if s == "" {
+			s = titleCaseCity(m.city)
+		}
 
 		printf(w, " Location: %v (%v), %v", m.countryInc, m.code, s)
 
@@ -790,33 +748,505 @@ func printMicPython(w io.Writer, m *market) {
 	printf(w, "\"\"\"\n")
 }
 
-func printBuffer(b *bytes.Buffer, filename string, formatSource bool) {
+// printCountriesPython emits a Country dataclass, a COUNTRIES dict keyed by
+// ISO 3166 alpha-2 code, and currency/continent/display_name accessors
+// grafted onto MIC, so downstream code can do e.g. mic.country.currency or
+// mic.display_name('fr') without re-deriving country metadata from the code.
+func printCountriesPython(b *bytes.Buffer, ms []*mic) {
+	printf(b, "from dataclasses import dataclass, field\n\n\n")
+
+	printf(b, "@dataclass(frozen=True)\n")
+	printf(b, "class Country:\n")
+	printf(b, "    \"\"\"Country metadata beyond the ISO 3166 alpha-2 code: alpha-3 and numeric-3\n")
+	printf(b, "    codes, the primary ISO 4217 currency, international calling code,\n")
+	printf(b, "    continent and subregion, and a locale -> localized name table.\"\"\"\n\n")
+	printf(b, "    code: str\n")
+	printf(b, "    alpha3: str\n")
+	printf(b, "    numeric: str\n")
+	printf(b, "    currency: str\n")
+	printf(b, "    calling_code: str\n")
+	printf(b, "    continent: str\n")
+	printf(b, "    subregion: str\n")
+	printf(b, "    names: dict = field(default_factory=dict)\n\n")
+	printf(b, "    def display_name(self, locale: str = 'en') -> str:\n")
+	printf(b, "        \"\"\"Returns the country name localized to locale, falling back to English.\"\"\"\n")
+	printf(b, "        return self.names.get(locale, self.names['en'])\n\n\n")
+
+	seen := map[string]bool{}
+
+	printf(b, "COUNTRIES = {\n")
+
+	for _, m := range ms {
+		printCountryPython(b, m.oper, seen)
+
+		for _, s := range m.segs {
+			printCountryPython(b, s, seen)
+		}
+	}
+
+	printf(b, "}\n\n")
+
+	printf(b, "# Grafted onto MIC rather than listed as a MIC field, since the country\n")
+	printf(b, "# metadata is shared by every MIC in the same country and COUNTRIES is the\n")
+	printf(b, "# single source of truth for it.\n")
+	printf(b, "MIC.country = property(lambda self: COUNTRIES[self.code])\n")
+	printf(b, "MIC.currency = property(lambda self: COUNTRIES[self.code].currency)\n")
+	printf(b, "MIC.continent = property(lambda self: COUNTRIES[self.code].continent)\n")
+	printf(b, "MIC.display_name = lambda self, locale='en': COUNTRIES[self.code].display_name(locale)\n\n\n")
+}
+
+func printCountryPython(b *bytes.Buffer, m *market, seen map[string]bool) {
+	if seen[m.code] {
+		return
+	}
+
+	seen[m.code] = true
+
+	ext := m.countryExt
+
+	printf(b, "    '%v': Country('%v', '%v', '%v', '%v', '%v', '%v', '%v', {\n",
+		m.code, m.code, ext.alpha3, ext.numeric, ext.currency, ext.callingCode, ext.continent, ext.subregion)
+
+	for _, locale := range countryExtLocales {
+		printf(b, "        '%v': '%v',\n", locale, ext.names[locale])
+	}
+
+	printf(b, "    }),\n")
+}
+
+// printSessionsPython emits _CALENDARS (the raw sessions.yaml data, keyed by
+// operating MIC) plus the holiday-rule evaluation and session-lookup helpers
+// that implement MIC.is_open/next_open/next_close/sessions_between off it.
+// The rule evaluation (Easter, nth-weekday, lunar new year) is reimplemented
+// in Python here rather than pre-baked into dates, so it stays correct for
+// any year, mirroring package sessions' Go-side logic.
+func printSessionsPython(b *bytes.Buffer, ms []*mic) {
+	printf(b, "from datetime import date, datetime, timedelta\n\n\n")
+
+	printf(b, "# _CALENDARS holds the raw trading-hours and holiday-calendar data from\n")
+	printf(b, "# sessions.yaml, keyed by operating MIC. Only operating MICs sessions.yaml\n")
+	printf(b, "# covers get one; MIC.is_open() and friends raise LookupError for the rest.\n")
+	printf(b, "_CALENDARS = {\n")
+
+	for _, m := range ms {
+		if m.cal != nil {
+			printCalendarPython(b, m.cal)
+		}
+	}
+
+	printf(b, "}\n\n")
+
+	printf(b, "# Gregorian date of Lunar New Year, for 'lunar-new-year' holiday rules.\n")
+	printf(b, "# A year absent here makes _holiday_dates skip that rule for that year.\n")
+	printf(b, "_LUNAR_NEW_YEAR = {\n")
+
+	for _, y := range sortedLunarNewYearYears() {
+		printf(b, "    %v: '%v',\n", y, sessions.LunarNewYear[y].Format("2006-01-02"))
+	}
+
+	printf(b, "}\n\n\n")
+
+	printf(b, "def _easter_sunday(year):\n")
+	printf(b, "    \"\"\"Gregorian Easter Sunday via the Meeus/Jones/Butcher algorithm.\"\"\"\n")
+	printf(b, "    a = year %% 19\n")
+	printf(b, "    b = year // 100\n")
+	printf(b, "    c = year %% 100\n")
+	printf(b, "    d = b // 4\n")
+	printf(b, "    e = b %% 4\n")
+	printf(b, "    f = (b + 8) // 25\n")
+	printf(b, "    g = (b - f + 1) // 3\n")
+	printf(b, "    h = (19 * a + b - d - g + 15) %% 30\n")
+	printf(b, "    i = c // 4\n")
+	printf(b, "    k = c %% 4\n")
+	printf(b, "    l = (32 + 2 * e + 2 * i - h - k) %% 7\n")
+	printf(b, "    m = (a + 11 * h + 22 * l) // 451\n")
+	printf(b, "    month = (h + l - 7 * m + 114) // 31\n")
+	printf(b, "    day = (h + l - 7 * m + 114) %% 31 + 1\n")
+	printf(b, "    return date(year, month, day)\n\n\n")
+
+	printf(b, "def _nth_weekday_of_month(year, month, weekday, nth):\n")
+	printf(b, "    \"\"\"weekday follows date.weekday() (Monday=0); nth may be negative (-1 = last).\"\"\"\n")
+	printf(b, "    if nth > 0:\n")
+	printf(b, "        first = date(year, month, 1)\n")
+	printf(b, "        offset = (weekday - first.weekday()) %% 7\n")
+	printf(b, "        return first + timedelta(days=offset + 7 * (nth - 1))\n\n")
+	printf(b, "    next_month = date(year + 1, 1, 1) if month == 12 else date(year, month + 1, 1)\n")
+	printf(b, "    offset = (next_month.weekday() - weekday) %% 7\n")
+	printf(b, "    if offset == 0:\n")
+	printf(b, "        offset = 7\n")
+	printf(b, "    last = next_month - timedelta(days=offset)\n\n")
+	printf(b, "    return last + timedelta(days=7 * (nth + 1))\n\n\n")
+
+	printf(b, "def _holiday_dates(operating_mic, year):\n")
+	printf(b, "    cal = _CALENDARS[operating_mic]\n")
+	printf(b, "    dates = set()\n\n")
+	printf(b, "    for rule_type, params in cal['holidays']:\n")
+	printf(b, "        if rule_type == 'fixed':\n")
+	printf(b, "            dates.add(date(year, params['month'], params['day']))\n")
+	printf(b, "        elif rule_type == 'nth-weekday':\n")
+	printf(b, "            dates.add(_nth_weekday_of_month(year, params['month'], params['weekday'], params['nth']))\n")
+	printf(b, "        elif rule_type == 'easter-relative':\n")
+	printf(b, "            dates.add(_easter_sunday(year) + timedelta(days=params['offset']))\n")
+	printf(b, "        elif rule_type == 'lunar-new-year':\n")
+	printf(b, "            base = _LUNAR_NEW_YEAR.get(year)\n")
+	printf(b, "            if base is not None:\n")
+	printf(b, "                dates.add(date.fromisoformat(base) + timedelta(days=params['offset']))\n\n")
+	printf(b, "    for d in cal['holiday_overrides']:\n")
+	printf(b, "        d2 = date.fromisoformat(d)\n")
+	printf(b, "        if d2.year == year:\n")
+	printf(b, "            dates.add(d2)\n\n")
+	printf(b, "    return dates\n\n\n")
+
+	printf(b, "def _day_sessions(operating_mic, local_date):\n")
+	printf(b, "    \"\"\"This MIC's sessions on local_date, as (name, open_minute, close_minute)\n")
+	printf(b, "    triples, with half-day and lunch-break adjustments applied. Empty on a\n")
+	printf(b, "    holiday.\"\"\"\n")
+	printf(b, "    cal = _CALENDARS[operating_mic]\n\n")
+	printf(b, "    if local_date in _holiday_dates(operating_mic, local_date.year):\n")
+	printf(b, "        return []\n\n")
+	printf(b, "    sessions = list(cal['sessions'])\n\n")
+	printf(b, "    close_override = cal['half_days'].get(local_date.isoformat())\n")
+	printf(b, "    if close_override is not None and sessions:\n")
+	printf(b, "        name, open_minute, _ = sessions[-1]\n")
+	printf(b, "        sessions[-1] = (name, open_minute, close_override)\n\n")
+	printf(b, "    lunch = cal['lunch']\n")
+	printf(b, "    if lunch is None:\n")
+	printf(b, "        return sessions\n\n")
+	printf(b, "    lunch_open, lunch_close = lunch\n")
+	printf(b, "    out = []\n\n")
+	printf(b, "    for name, open_minute, close_minute in sessions:\n")
+	printf(b, "        if lunch_open <= open_minute or lunch_close >= close_minute:\n")
+	printf(b, "            out.append((name, open_minute, close_minute))\n")
+	printf(b, "        else:\n")
+	printf(b, "            out.append((name, open_minute, lunch_open))\n")
+	printf(b, "            out.append((name, lunch_close, close_minute))\n\n")
+	printf(b, "    return out\n\n\n")
+
+	printf(b, "def _calendar_for(mic_instance):\n")
+	printf(b, "    cal = _CALENDARS.get(mic_instance.operating_mic)\n")
+	printf(b, "    if cal is None:\n")
+	printf(b, "        raise LookupError(f\"no trading calendar for operating MIC '{mic_instance.operating_mic}'\")\n\n")
+	printf(b, "    return cal\n\n\n")
+
+	printf(b, "def _local_datetime(mic_instance, dt):\n")
+	printf(b, "    if dt is None:\n")
+	printf(b, "        return datetime.now(mic_instance.tzinfo())\n\n")
+	printf(b, "    if dt.tzinfo is not None:\n")
+	printf(b, "        return dt.astimezone(mic_instance.tzinfo())\n\n")
+	printf(b, "    return dt\n\n\n")
+
+	printf(b, "_MAX_LOOKAHEAD_DAYS = 14\n\n\n")
+
+	printf(b, "def _is_open(mic_instance, dt=None):\n")
+	printf(b, "    _calendar_for(mic_instance)\n\n")
+	printf(b, "    local = _local_datetime(mic_instance, dt)\n")
+	printf(b, "    minute = local.hour * 60 + local.minute\n\n")
+	printf(b, "    for _, open_minute, close_minute in _day_sessions(mic_instance.operating_mic, local.date()):\n")
+	printf(b, "        if open_minute <= minute < close_minute:\n")
+	printf(b, "            return True\n\n")
+	printf(b, "    return False\n\n\n")
+
+	printf(b, "def _next_open(mic_instance, after=None):\n")
+	printf(b, "    _calendar_for(mic_instance)\n\n")
+	printf(b, "    local = _local_datetime(mic_instance, after)\n\n")
+	printf(b, "    for day in range(_MAX_LOOKAHEAD_DAYS + 1):\n")
+	printf(b, "        d = local.date() + timedelta(days=day)\n\n")
+	printf(b, "        for _, open_minute, _ in _day_sessions(mic_instance.operating_mic, d):\n")
+	printf(b, "            candidate = datetime.combine(d, datetime.min.time(), tzinfo=local.tzinfo) + timedelta(minutes=open_minute)\n")
+	printf(b, "            if candidate >= local:\n")
+	printf(b, "                return candidate\n\n")
+	printf(b, "    raise LookupError('no session found within the lookahead window')\n\n\n")
+
+	printf(b, "def _next_close(mic_instance, after=None):\n")
+	printf(b, "    _calendar_for(mic_instance)\n\n")
+	printf(b, "    local = _local_datetime(mic_instance, after)\n\n")
+	printf(b, "    for day in range(_MAX_LOOKAHEAD_DAYS + 1):\n")
+	printf(b, "        d = local.date() + timedelta(days=day)\n\n")
+	printf(b, "        for _, _, close_minute in _day_sessions(mic_instance.operating_mic, d):\n")
+	printf(b, "            candidate = datetime.combine(d, datetime.min.time(), tzinfo=local.tzinfo) + timedelta(minutes=close_minute)\n")
+	printf(b, "            if candidate > local:\n")
+	printf(b, "                return candidate\n\n")
+	printf(b, "    raise LookupError('no session found within the lookahead window')\n\n\n")
+
+	printf(b, "def _sessions_between(mic_instance, a, b):\n")
+	printf(b, "    _calendar_for(mic_instance)\n\n")
+	printf(b, "    a = _local_datetime(mic_instance, a)\n")
+	printf(b, "    b = _local_datetime(mic_instance, b)\n\n")
+	printf(b, "    windows = []\n")
+	printf(b, "    d = a.date()\n\n")
+	printf(b, "    while d <= b.date():\n")
+	printf(b, "        for name, open_minute, close_minute in _day_sessions(mic_instance.operating_mic, d):\n")
+	printf(b, "            open_dt = datetime.combine(d, datetime.min.time(), tzinfo=a.tzinfo) + timedelta(minutes=open_minute)\n")
+	printf(b, "            close_dt = datetime.combine(d, datetime.min.time(), tzinfo=a.tzinfo) + timedelta(minutes=close_minute)\n\n")
+	printf(b, "            if close_dt > a and open_dt < b:\n")
+	printf(b, "                windows.append((name, open_dt, close_dt))\n\n")
+	printf(b, "        d += timedelta(days=1)\n\n")
+	printf(b, "    return windows\n\n\n")
+
+	printf(b, "MIC.is_open = _is_open\n")
+	printf(b, "MIC.next_open = _next_open\n")
+	printf(b, "MIC.next_close = _next_close\n")
+	printf(b, "MIC.sessions_between = _sessions_between\n\n\n")
+}
+
+func printCalendarPython(b *bytes.Buffer, c *sessions.Calendar) {
+	printf(b, "    '%v': {\n", c.OperatingMIC)
+	printf(b, "        'sessions': [\n")
+
+	for _, s := range c.Sessions {
+		printf(b, "            ('%v', %v, %v),\n", s.Name, s.Open, s.Close)
+	}
+
+	printf(b, "        ],\n")
+
+	if c.Lunch == nil {
+		printf(b, "        'lunch': None,\n")
+	} else {
+		printf(b, "        'lunch': (%v, %v),\n", c.Lunch.Open, c.Lunch.Close)
+	}
+
+	printf(b, "        'half_days': {\n")
+
+	for _, d := range sortedHalfDays(c.HalfDays) {
+		printf(b, "            '%v': %v,\n", d, c.HalfDays[d])
+	}
+
+	printf(b, "        },\n")
+
+	printf(b, "        'holidays': [\n")
+
+	for _, r := range c.Rules {
+		printf(b, "            ('%v', %v),\n", r.Type, holidayRuleParamsPython(r))
+	}
+
+	printf(b, "        ],\n")
+
+	printf(b, "        'holiday_overrides': [\n")
+
+	for _, o := range c.Overrides {
+		printf(b, "            '%v',\n", o.Date.Format("2006-01-02"))
+	}
+
+	printf(b, "        ],\n")
+	printf(b, "    },\n")
+}
+
+// holidayRuleParamsPython renders a HolidayRule's parameters as the Python
+// dict literal _holiday_dates expects, in the weekday convention
+// date.weekday() uses (Monday=0), rather than Go's (Sunday=0).
+func holidayRuleParamsPython(r sessions.HolidayRule) string {
+	switch r.Type {
+	case "fixed":
+		return fmt.Sprintf("{'month': %v, 'day': %v}", int(r.Month), r.Day)
+	case "nth-weekday":
+		return fmt.Sprintf("{'month': %v, 'weekday': %v, 'nth': %v}", int(r.Month), (int(r.Weekday)+6)%7, r.Nth)
+	default: // "easter-relative", "lunar-new-year"
+		return fmt.Sprintf("{'offset': %v}", r.Offset)
+	}
+}
+
+func sortedHalfDays(hd map[string]int) []string {
+	ds := make([]string, 0, len(hd))
+	for d := range hd {
+		ds = append(ds, d)
+	}
+
+	sort.Strings(ds)
+
+	return ds
+}
+
+func sortedLunarNewYearYears() []int {
+	ys := make([]int, 0, len(sessions.LunarNewYear))
+	for y := range sessions.LunarNewYear {
+		ys = append(ys, y)
+	}
+
+	sort.Ints(ys)
+
+	return ys
+}
+
+// kdPoint is one operating MIC's venue location, indexed by kdNode.
+type kdPoint struct {
+	mic      string
+	lat, lon float64
+}
+
+// kdNode is a node of a 2-D k-d tree over kdPoints, split alternately on
+// latitude (even depth) and longitude (odd depth), matching the axis
+// convention the generated Python nearest()/within_radius_km() use to
+// traverse it.
+type kdNode struct {
+	mic      string
+	lat, lon float64
+	left     *kdNode
+	right    *kdNode
+}
+
+// buildKDTree builds a balanced k-d tree by recursively splitting points on
+// the median of the current depth's axis. It sorts (and so reorders) points.
+func buildKDTree(points []kdPoint, depth int) *kdNode {
+	if len(points) == 0 {
+		return nil
+	}
+
+	if depth%2 == 0 {
+		sort.Slice(points, func(i, j int) bool { return points[i].lat < points[j].lat })
+	} else {
+		sort.Slice(points, func(i, j int) bool { return points[i].lon < points[j].lon })
+	}
+
+	mid := len(points) / 2
+
+	return &kdNode{
+		mic:   points[mid].mic,
+		lat:   points[mid].lat,
+		lon:   points[mid].lon,
+		left:  buildKDTree(points[:mid], depth+1),
+		right: buildKDTree(points[mid+1:], depth+1),
+	}
+}
+
+// printSpatialPython emits _MIC_TREE - a k-d tree over every operating MIC's
+// venue coordinates, serialized as a plain nested Python tuple literal so no
+// numpy/scipy is needed at import time - plus nearest()/within_radius_km()
+// to search it and by_ip() to resolve a caller's IP to a venue via an
+// optional MaxMind GeoIP2 database.
+func printSpatialPython(b *bytes.Buffer, ms []*mic) {
+	points := make([]kdPoint, 0, len(ms))
+	for _, m := range ms {
+		points = append(points, kdPoint{mic: m.oper.mic, lat: m.oper.lat, lon: m.oper.lon})
+	}
+
+	tree := buildKDTree(points, 0)
+
+	printf(b, "# _MIC_TREE is a k-d tree over every operating MIC's venue coordinates:\n")
+	printf(b, "# (mic_code, lat, lon, left, right) tuples, None where a child is absent.\n")
+	printf(b, "# Depth alternates the split axis starting with latitude, same as a\n")
+	printf(b, "# textbook k-d tree; see _kd_nearest/_kd_within_radius below.\n")
+	printf(b, "_MIC_TREE = ")
+	printKDNodePython(b, tree)
+	printf(b, "\n\n\n")
+
+	printf(b, "_KM_PER_DEGREE = 111.0  # upper bound; never prunes a closer point away\n\n\n")
+
+	printf(b, "def _haversine_km(lat1, lon1, lat2, lon2):\n")
+	printf(b, "    \"\"\"Great-circle distance between two coordinates, in kilometers.\"\"\"\n")
+	printf(b, "    r = 6371.0088\n")
+	printf(b, "    p1, p2 = math.radians(lat1), math.radians(lat2)\n")
+	printf(b, "    dphi = math.radians(lat2 - lat1)\n")
+	printf(b, "    dlambda = math.radians(lon2 - lon1)\n")
+	printf(b, "    a = math.sin(dphi / 2) ** 2 + math.cos(p1) * math.cos(p2) * math.sin(dlambda / 2) ** 2\n\n")
+	printf(b, "    return 2 * r * math.asin(math.sqrt(a))\n\n\n")
+
+	printf(b, "def _kd_nearest(node, lat, lon, k, heap, depth=0):\n")
+	printf(b, "    if node is None:\n")
+	printf(b, "        return\n\n")
+	printf(b, "    mic_code, clat, clon, left, right = node\n")
+	printf(b, "    d = _haversine_km(lat, lon, clat, clon)\n\n")
+	printf(b, "    item = (-d, mic_code)\n")
+	printf(b, "    if len(heap) < k:\n")
+	printf(b, "        heapq.heappush(heap, item)\n")
+	printf(b, "    elif d < -heap[0][0]:\n")
+	printf(b, "        heapq.heapreplace(heap, item)\n\n")
+	printf(b, "    axis_value, query_value = (clat, lat) if depth %% 2 == 0 else (clon, lon)\n")
+	printf(b, "    near, far = (left, right) if query_value < axis_value else (right, left)\n\n")
+	printf(b, "    _kd_nearest(near, lat, lon, k, heap, depth + 1)\n\n")
+	printf(b, "    axis_gap_km = abs(axis_value - query_value) * _KM_PER_DEGREE\n")
+	printf(b, "    if len(heap) < k or axis_gap_km < -heap[0][0]:\n")
+	printf(b, "        _kd_nearest(far, lat, lon, k, heap, depth + 1)\n\n\n")
+
+	printf(b, "def nearest(lat, lon, k=5):\n")
+	printf(b, "    \"\"\"Returns up to k (MIC, distance_km) pairs closest to (lat, lon),\n")
+	printf(b, "    nearest first.\"\"\"\n")
+	printf(b, "    heap = []\n")
+	printf(b, "    _kd_nearest(_MIC_TREE, lat, lon, k, heap)\n\n")
+	printf(b, "    results = [(MICs[mic_code], -negd) for negd, mic_code in heap]\n")
+	printf(b, "    results.sort(key=lambda r: r[1])\n\n")
+	printf(b, "    return results\n\n\n")
+
+	printf(b, "def _kd_within_radius(node, lat, lon, radius_km, depth, out):\n")
+	printf(b, "    if node is None:\n")
+	printf(b, "        return\n\n")
+	printf(b, "    mic_code, clat, clon, left, right = node\n")
+	printf(b, "    d = _haversine_km(lat, lon, clat, clon)\n")
+	printf(b, "    if d <= radius_km:\n")
+	printf(b, "        out.append((mic_code, d))\n\n")
+	printf(b, "    axis_value, query_value = (clat, lat) if depth %% 2 == 0 else (clon, lon)\n")
+	printf(b, "    near, far = (left, right) if query_value < axis_value else (right, left)\n\n")
+	printf(b, "    _kd_within_radius(near, lat, lon, radius_km, depth + 1, out)\n\n")
+	printf(b, "    axis_gap_km = abs(axis_value - query_value) * _KM_PER_DEGREE\n")
+	printf(b, "    if axis_gap_km <= radius_km:\n")
+	printf(b, "        _kd_within_radius(far, lat, lon, radius_km, depth + 1, out)\n\n\n")
+
+	printf(b, "def within_radius_km(lat, lon, radius_km):\n")
+	printf(b, "    \"\"\"Returns every (MIC, distance_km) pair within radius_km of (lat, lon),\n")
+	printf(b, "    nearest first.\"\"\"\n")
+	printf(b, "    out = []\n")
+	printf(b, "    _kd_within_radius(_MIC_TREE, lat, lon, radius_km, 0, out)\n")
+	printf(b, "    out.sort(key=lambda r: r[1])\n\n")
+	printf(b, "    return [(MICs[mic_code], d) for mic_code, d in out]\n\n\n")
+
+	printf(b, "def by_ip(ip, geoip_db_path):\n")
+	printf(b, "    \"\"\"Resolves ip's location via the MaxMind GeoIP2 database at\n")
+	printf(b, "    geoip_db_path and returns nearest()'s candidate venues for it.\n")
+	printf(b, "    Requires the optional 'geoip2' package; geoip_db_path is never\n")
+	printf(b, "    defaulted so the database file and its license terms are always an\n")
+	printf(b, "    explicit choice by the caller.\"\"\"\n")
+	printf(b, "    try:\n")
+	printf(b, "        import geoip2.database\n")
+	printf(b, "    except ImportError as exc:\n")
+	printf(b, "        raise ImportError(\"by_ip requires the optional 'geoip2' package\") from exc\n\n")
+	printf(b, "    with geoip2.database.Reader(geoip_db_path) as reader:\n")
+	printf(b, "        response = reader.city(ip)\n\n")
+	printf(b, "    lat = response.location.latitude\n")
+	printf(b, "    lon = response.location.longitude\n\n")
+	printf(b, "    if lat is None or lon is None:\n")
+	printf(b, "        raise LookupError(f\"no coordinates resolved for ip '{ip}'\")\n\n")
+	printf(b, "    return nearest(lat, lon)\n\n\n")
+}
+
+func printKDNodePython(b *bytes.Buffer, n *kdNode) {
+	if n == nil {
+		printf(b, "None")
+
+		return
+	}
+
+	printf(b, "('%v', %v, %v, ", n.mic, n.lat, n.lon)
+	printKDNodePython(b, n.left)
+	printf(b, ", ")
+	printKDNodePython(b, n.right)
+	printf(b, ")")
+}
+
+// printBuffer writes b to filename, optionally passing it through formatter
+// first (e.g. go/format.Source for the Go backend, nil for backends that
+// need no post-processing).
+func printBuffer(b *bytes.Buffer, filename string, formatter func([]byte) ([]byte, error)) {
 	f, err := os.Create(filename)
 	if err != nil {
 		die(err)
 	}
 	defer f.Close()
 
-	if formatSource {
-		data, err := format.Source(b.Bytes())
-		if err != nil {
-			die(err)
-		}
+	data := b.Bytes()
 
-		_, err = f.Write(data)
-		if err != nil {
-			die(err)
-		}
-	} else {
-		_, err = f.Write(b.Bytes())
+	if formatter != nil {
+		data, err = formatter(data)
 		if err != nil {
 			die(err)
 		}
 	}
+
+	if _, err := f.Write(data); err != nil {
+		die(err)
+	}
 }
 
 //nolint:funlen
-func printMicsPython(filename string, ms []*mic, ics []string, ecs []string, tzmics []*tzmic) {
+func printMicsPython(filename string, ms []*mic, ics []string, ecs []string, tzmics []*tzmic, curmics []*curmic) {
 	var b bytes.Buffer
 
 	printf(&b, "# Code generated by 'go generate'; DO NOT EDIT.\n")
@@ -837,6 +1267,23 @@ func printMicsPython(filename string, ms []*mic, ics []string, ecs []string, tzm
 	printf(&b, "# MICs are generated for countries with the following ISO 3166 alpha-2 codes:\n")
 	printf(&b, "# %v.\n", concatenateCountries(ics))
 
+	printf(&b, "#\n")
+	printf(&b, "# Each MIC carries an IANA time zone identifier (e.g. 'Europe/Amsterdam') rather\n")
+	printf(&b, "# than a fixed GMT offset, so Market.now()/Market.localize(dt) stay correct across\n")
+	printf(&b, "# DST transitions. Market.tzinfo() resolves the identifier to a datetime.tzinfo via\n")
+	printf(&b, "# zoneinfo.ZoneInfo, falling back to the backports.zoneinfo package on Python < 3.9.\n")
+	printf(&b, "try:\n")
+	printf(&b, "    from zoneinfo import ZoneInfo\n")
+	printf(&b, "except ImportError:  # Python < 3.9\n")
+	printf(&b, "    from backports.zoneinfo import ZoneInfo\n\n")
+
+	printf(&b, "import heapq\n")
+	printf(&b, "import math\n\n\n")
+
+	printCountriesPython(&b, ms)
+	printSessionsPython(&b, ms)
+	printSpatialPython(&b, ms)
+
 	printf(&b, "class MICs(metaclass=SubscriptableType):\n\n")
 
 	for _, m := range ms {
@@ -847,5 +1294,40 @@ func printMicsPython(filename string, ms []*mic, ics []string, ecs []string, tzm
 		}
 	}
 
-	printBuffer(&b, filename, false)
+	printf(&b, "\n")
+
+	printCurrenciesPython(&b, curmics)
+
+	printBuffer(&b, filename, nil)
+}
+
+// printCurrenciesPython emits MICS_BY_CURRENCY, keyed by ISO 4217 currency
+// code, grouping every MIC (operating and segment) that trades in it. It
+// runs after the MICs class body above so it can reference MICs.<mic>
+// instances directly rather than re-looking them up by code.
+func printCurrenciesPython(b *bytes.Buffer, curmics []*curmic) {
+	printf(b, "# MICS_BY_CURRENCY groups every MIC (operating and segment) by ISO 4217\n")
+	printf(b, "# trading currency, e.g. MICS_BY_CURRENCY['EUR'] lists every MIC settling in\n")
+	printf(b, "# euros. A MIC trading in more than one currency appears under each.\n")
+	printf(b, "MICS_BY_CURRENCY = {\n")
+
+	for _, c := range curmics {
+		printf(b, "    '%v': (", c.currency)
+
+		for i, m := range c.markets {
+			if i > 0 {
+				printf(b, ", ")
+			}
+
+			printf(b, "MICs.%v", safeMic(m.mic))
+		}
+
+		if len(c.markets) == 1 {
+			printf(b, ",")
+		}
+
+		printf(b, "),\n")
+	}
+
+	printf(b, "}\n")
 }
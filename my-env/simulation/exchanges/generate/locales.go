@@ -0,0 +1,38 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// nameLocales lists the locales city_names.csv carries, in column order
+// after the city column, and is also the canonical order names maps are
+// printed in regardless of which subset -locales selects.
+var nameLocales = []string{"en", "de", "fr", "es", "ja", "zh-Hans"}
+
+var errUnknownLocale = errors.New("unknown locale, want one of " + strings.Join(nameLocales, ", "))
+
+// resolveLocales parses a comma-separated -locales value (e.g. "en,de,ja")
+// into the subset of nameLocales it names, in the order given.
+func resolveLocales(locales string) ([]string, error) {
+	known := make(map[string]bool, len(nameLocales))
+	for _, l := range nameLocales {
+		known[l] = true
+	}
+
+	names := strings.Split(locales, ",")
+	selected := make([]string, 0, len(names))
+
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+
+		if !known[name] {
+			return nil, fmt.Errorf("%q: %w", name, errUnknownLocale)
+		}
+
+		selected = append(selected, name)
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+// kdTestPoints is a small, hand-picked set of real venue-like coordinates
+// spanning both hemispheres and the antimeridian area, enough to exercise
+// both split axes (buildKDTree alternates latitude/longitude by depth).
+func kdTestPoints() []kdPoint {
+	return []kdPoint{
+		{mic: "XNYS", lat: 40.7128, lon: -74.0060},  // New York
+		{mic: "XLON", lat: 51.5072, lon: -0.1276},   // London
+		{mic: "XTKS", lat: 35.6762, lon: 139.6503},  // Tokyo
+		{mic: "XASX", lat: -33.8688, lon: 151.2093}, // Sydney
+		{mic: "XBOM", lat: 19.0760, lon: 72.8777},   // Mumbai
+		{mic: "XSGO", lat: -33.4489, lon: -70.6693}, // Santiago
+		{mic: "XJSE", lat: -26.2041, lon: 28.0473},  // Johannesburg
+		{mic: "XTSE", lat: 43.6532, lon: -79.3832},  // Toronto
+	}
+}
+
+// checkKDTreeInvariant recursively verifies that every node's subtree obeys
+// buildKDTree's split rule: at a given depth the axis alternates between
+// latitude (even) and longitude (odd), and every point in left is <= the
+// node's value on that axis while every point in right is >= it.
+func checkKDTreeInvariant(t *testing.T, n *kdNode, depth int, latBound, lonBound *float64, latIsUpper, lonIsUpper bool) {
+	t.Helper()
+
+	if n == nil {
+		return
+	}
+
+	if latBound != nil {
+		if latIsUpper && n.lat > *latBound {
+			t.Errorf("node %v at depth %v: lat %v exceeds upper bound %v", n.mic, depth, n.lat, *latBound)
+		}
+
+		if !latIsUpper && n.lat < *latBound {
+			t.Errorf("node %v at depth %v: lat %v below lower bound %v", n.mic, depth, n.lat, *latBound)
+		}
+	}
+
+	if lonBound != nil {
+		if lonIsUpper && n.lon > *lonBound {
+			t.Errorf("node %v at depth %v: lon %v exceeds upper bound %v", n.mic, depth, n.lon, *lonBound)
+		}
+
+		if !lonIsUpper && n.lon < *lonBound {
+			t.Errorf("node %v at depth %v: lon %v below lower bound %v", n.mic, depth, n.lon, *lonBound)
+		}
+	}
+
+	if depth%2 == 0 {
+		checkKDTreeInvariant(t, n.left, depth+1, &n.lat, lonBound, true, lonIsUpper)
+		checkKDTreeInvariant(t, n.right, depth+1, &n.lat, lonBound, false, lonIsUpper)
+	} else {
+		checkKDTreeInvariant(t, n.left, depth+1, latBound, &n.lon, latIsUpper, true)
+		checkKDTreeInvariant(t, n.right, depth+1, latBound, &n.lon, latIsUpper, false)
+	}
+}
+
+func TestBuildKDTreeSplitInvariant(t *testing.T) {
+	points := kdTestPoints()
+
+	tree := buildKDTree(points, 0)
+	if tree == nil {
+		t.Fatal("buildKDTree returned nil for a non-empty point set")
+	}
+
+	checkKDTreeInvariant(t, tree, 0, nil, nil, false, false)
+}
+
+func TestBuildKDTreeEmpty(t *testing.T) {
+	if got := buildKDTree(nil, 0); got != nil {
+		t.Errorf("buildKDTree(nil) = %+v, want nil", got)
+	}
+}
+
+func TestBuildKDTreeContainsEveryPoint(t *testing.T) {
+	points := kdTestPoints()
+
+	tree := buildKDTree(append([]kdPoint(nil), points...), 0)
+
+	seen := map[string]bool{}
+
+	var walk func(n *kdNode)
+
+	walk = func(n *kdNode) {
+		if n == nil {
+			return
+		}
+
+		seen[n.mic] = true
+
+		walk(n.left)
+		walk(n.right)
+	}
+
+	walk(tree)
+
+	for _, p := range points {
+		if !seen[p.mic] {
+			t.Errorf("buildKDTree dropped point %v", p.mic)
+		}
+	}
+
+	if len(seen) != len(points) {
+		t.Errorf("buildKDTree produced %v nodes, want %v", len(seen), len(points))
+	}
+}
+
+// haversineKm mirrors printSpatialPython's _haversine_km, since that is the
+// only place this repo's search logic for the tree actually lives (the
+// generated Python); duplicating it here lets the Go test check the tree
+// buildKDTree produces is genuinely searchable with that formula.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const r = 6371.0088
+
+	p1, p2 := lat1*math.Pi/180, lat2*math.Pi/180
+	dphi := (lat2 - lat1) * math.Pi / 180
+	dlambda := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Pow(math.Sin(dphi/2), 2) + math.Cos(p1)*math.Cos(p2)*math.Pow(math.Sin(dlambda/2), 2)
+
+	return 2 * r * math.Asin(math.Sqrt(a))
+}
+
+// kdNearest mirrors printSpatialPython's _kd_nearest search, using the same
+// per-depth axis-gap pruning bound, so this test exercises the tree
+// buildKDTree produces exactly the way the generated Python will.
+func kdNearest(n *kdNode, lat, lon float64, depth int, best *kdPoint, bestDist *float64) {
+	if n == nil {
+		return
+	}
+
+	d := haversineKm(lat, lon, n.lat, n.lon)
+	if best == nil || d < *bestDist {
+		*best = kdPoint{mic: n.mic, lat: n.lat, lon: n.lon}
+		*bestDist = d
+	}
+
+	const kmPerDegree = 111.0
+
+	axisValue, queryValue := n.lat, lat
+	if depth%2 != 0 {
+		axisValue, queryValue = n.lon, lon
+	}
+
+	near, far := n.left, n.right
+	if queryValue >= axisValue {
+		near, far = n.right, n.left
+	}
+
+	kdNearest(near, lat, lon, depth+1, best, bestDist)
+
+	axisGapKm := math.Abs(axisValue-queryValue) * kmPerDegree
+	if axisGapKm < *bestDist {
+		kdNearest(far, lat, lon, depth+1, best, bestDist)
+	}
+}
+
+func TestKDNearestMatchesBruteForce(t *testing.T) {
+	points := kdTestPoints()
+	tree := buildKDTree(append([]kdPoint(nil), points...), 0)
+
+	queries := []struct {
+		name     string
+		lat, lon float64
+	}{
+		{"near New York", 40.0, -75.0},
+		{"near Tokyo", 35.0, 140.0},
+		{"near Sydney", -34.0, 151.0},
+		{"mid-Atlantic", 0, -30},
+	}
+
+	for _, q := range queries {
+		t.Run(q.name, func(t *testing.T) {
+			var bruteBest kdPoint
+
+			bruteDist := math.Inf(1)
+
+			for _, p := range points {
+				d := haversineKm(q.lat, q.lon, p.lat, p.lon)
+				if d < bruteDist {
+					bruteBest, bruteDist = p, d
+				}
+			}
+
+			var treeBest kdPoint
+
+			treeDist := math.Inf(1)
+			kdNearest(tree, q.lat, q.lon, 0, &treeBest, &treeDist)
+
+			if treeBest.mic != bruteBest.mic {
+				t.Errorf("kdNearest(%v,%v) = %v (%.3fkm), want %v (%.3fkm, brute force)",
+					q.lat, q.lon, treeBest.mic, treeDist, bruteBest.mic, bruteDist)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package mic
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+var errInvalidRecord = errors.New("invalid ISO 10383 record")
+
+// parseCSV parses the ISO 10383 CSV format, applying the same field rules
+// the generate_mics_python tool's parseMarket uses, so a dataset refreshed
+// via Refresh is guaranteed to also be accepted by that generator.
+func parseCSV(data string) ([]*Market, error) {
+	ms := []*Market{}
+
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = ','
+	r.Comment = '#'
+	r.FieldsPerRecord = 13
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if ln == 1 {
+			continue // header row
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("line %v: %w", ln, err)
+		}
+
+		m, err := parseRecord(record, ln)
+		if err != nil {
+			return nil, err
+		}
+
+		ms = append(ms, m)
+	}
+
+	return ms, nil
+}
+
+//nolint:gomnd,cyclop
+func parseRecord(record []string, ln int) (*Market, error) {
+	country := strings.Trim(record[0], "\"")
+	if len(country) < 2 {
+		return nil, fmt.Errorf("line %v: country should have at least 2 characters: %w", ln, errInvalidRecord)
+	}
+
+	code := strings.Trim(record[1], "\"")
+	if len(code) != 2 {
+		return nil, fmt.Errorf("line %v: ISO 3166 country code should have 2 characters: %w", ln, errInvalidRecord)
+	}
+
+	code4 := strings.Trim(record[2], "\"")
+	if len(code4) != 4 {
+		return nil, fmt.Errorf("line %v: MIC should have 4 characters: %w", ln, errInvalidRecord)
+	}
+
+	opCode := strings.Trim(record[3], "\"")
+	if len(opCode) != 4 {
+		return nil, fmt.Errorf("line %v: operating MIC should have 4 characters: %w", ln, errInvalidRecord)
+	}
+
+	os := strings.Trim(record[4], "\"")
+	if os != "O" && os != "S" {
+		return nil, fmt.Errorf("line %v: O/S should be either 'O' or 'S', got '%v': %w", ln, os, errInvalidRecord)
+	}
+
+	if os == "O" && code4 != opCode {
+		return nil, fmt.Errorf("line %v: operating MIC should equal MIC, got '%v' and '%v': %w",
+			ln, code4, opCode, errInvalidRecord)
+	}
+
+	if os == "S" && code4 == opCode {
+		return nil, fmt.Errorf("line %v: segment MIC should differ from its operating MIC: %w", ln, errInvalidRecord)
+	}
+
+	statusDate := strings.Trim(record[9], "\"")
+	if len(statusDate) < 1 {
+		return nil, fmt.Errorf("line %v: status date should not be empty: %w", ln, errInvalidRecord)
+	}
+
+	status := strings.Trim(record[10], "\"")
+	if len(status) < 1 {
+		return nil, fmt.Errorf("line %v: status should not be empty: %w", ln, errInvalidRecord)
+	}
+
+	creationDate := strings.Trim(record[11], "\"")
+	if len(creationDate) < 1 {
+		return nil, fmt.Errorf("line %v: creation date should not be empty: %w", ln, errInvalidRecord)
+	}
+
+	comments := ""
+	if len(record) > 12 {
+		comments = strings.Trim(record[12], "\"")
+	}
+
+	return &Market{
+		Country:      country,
+		CountryCode:  code,
+		MIC:          code4,
+		OperatingMIC: opCode,
+		Operational:  os == "O",
+		Name:         strings.Trim(record[5], "\""),
+		Acronym:      strings.Trim(record[6], "\""),
+		City:         strings.Trim(record[7], "\""),
+		Website:      strings.ToLower(strings.Trim(record[8], "\"")),
+		StatusDate:   statusDate,
+		Status:       status,
+		CreationDate: creationDate,
+		Comments:     comments,
+	}, nil
+}
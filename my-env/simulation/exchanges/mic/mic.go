@@ -0,0 +1,155 @@
+// Package mic provides programmatic access to the ISO 10383 Market
+// Identifier Code dataset also used by the generate_mics_python code
+// generator. That tool only ever produces a Python module; this package
+// embeds the same parsed dataset and exposes it to Go callers directly,
+// with no CSV parsing or Python interop required at call time.
+package mic
+
+import (
+	_ "embed"
+	"strings"
+)
+
+const dataPublicationDate = "10-May-2021"
+
+const datasetPath = "ISO10383_MIC." + dataPublicationDate + ".csv"
+
+//go:embed ISO10383_MIC.10-May-2021.csv
+var datasetCSV string
+
+// Market is a single row of the ISO 10383 dataset: either an operating MIC
+// or a segment MIC belonging to one.
+type Market struct {
+	Country      string
+	CountryCode  string // ISO 3166 alpha-2
+	MIC          string
+	OperatingMIC string
+	Operational  bool // true for an operating MIC, false for a segment MIC
+	Name         string
+	Acronym      string
+	City         string
+	Website      string
+	StatusDate   string
+	Status       string
+	CreationDate string
+	Comments     string
+
+	tzname string // IANA time zone identifier resolved from City, see tz.go
+}
+
+// Segment is a non-operating MIC that belongs to an operating MIC, returned
+// by ByOperatingMIC.
+type Segment = Market
+
+var (
+	all    []*Market
+	byCode map[string]*Market
+	byOp   map[string][]*Market
+	byZone map[string][]*Market
+)
+
+func init() {
+	ms, err := parseCSV(datasetCSV)
+	if err != nil {
+		panic("mic: embedded dataset " + datasetPath + " is invalid: " + err.Error())
+	}
+
+	if err := resolveZones(ms); err != nil {
+		panic("mic: " + err.Error())
+	}
+
+	if err := validateZones(); err != nil {
+		panic("mic: " + err.Error())
+	}
+
+	index(ms)
+}
+
+func index(ms []*Market) {
+	all = ms
+	byCode = make(map[string]*Market, len(ms))
+	byOp = make(map[string][]*Market, len(ms))
+	byZone = make(map[string][]*Market, len(ms))
+
+	for _, m := range ms {
+		byCode[m.MIC] = m
+		byOp[m.OperatingMIC] = append(byOp[m.OperatingMIC], m)
+		byZone[m.tzname] = append(byZone[m.tzname], m)
+	}
+}
+
+// ByCode looks up a market by its own MIC, e.g. ByCode("XNAS").
+func ByCode(code string) (*Market, bool) {
+	m, ok := byCode[strings.ToUpper(code)]
+	return m, ok
+}
+
+// ByOperatingMIC returns every segment MIC belonging to the given operating
+// MIC, e.g. ByOperatingMIC("XNYS"). The operating MIC itself is not included.
+func ByOperatingMIC(op string) []*Segment {
+	op = strings.ToUpper(op)
+
+	segs := []*Segment{}
+
+	for _, m := range byOp[op] {
+		if !m.Operational {
+			segs = append(segs, m)
+		}
+	}
+
+	return segs
+}
+
+// ByCountry returns every market registered under the given ISO 3166
+// alpha-2 country code, e.g. ByCountry("US").
+func ByCountry(iso3166 string) []*Market {
+	iso3166 = strings.ToUpper(iso3166)
+
+	ms := []*Market{}
+
+	for _, m := range all {
+		if m.CountryCode == iso3166 {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms
+}
+
+// ByCity returns every market whose city matches exactly, e.g.
+// ByCity("LONDON").
+func ByCity(city string) []*Market {
+	city = strings.ToUpper(city)
+
+	ms := []*Market{}
+
+	for _, m := range all {
+		if m.City == city {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms
+}
+
+// SearchByName returns every market whose name contains substr, matched
+// case-insensitively.
+func SearchByName(substr string) []*Market {
+	substr = strings.ToUpper(substr)
+
+	ms := []*Market{}
+
+	for _, m := range all {
+		if strings.Contains(strings.ToUpper(m.Name), substr) {
+			ms = append(ms, m)
+		}
+	}
+
+	return ms
+}
+
+// All returns every market in the dataset, operating MICs and segments
+// alike, in the order they appear in the source file.
+func All() []*Market {
+	return append([]*Market(nil), all...)
+}
@@ -0,0 +1,62 @@
+package mic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// publicationURL is where ISO publishes the canonical ISO 10383 CSV.
+const publicationURL = "https://www.iso20022.org/sites/default/files/ISO10383_MIC/ISO10383_MIC.csv"
+
+// Refresh downloads the latest ISO 10383 CSV (pass "" for url to use
+// publicationURL), revalidates every record with the same rules parseCSV
+// applies to the embedded snapshot, and overwrites the snapshot file at
+// path with the result.
+//
+// Refresh only rewrites the file on disk: the running process keeps using
+// the dataset it was built with, since //go:embed is resolved at compile
+// time. Callers must rebuild (and bump dataPublicationDate and the
+// //go:embed filename) for a refreshed snapshot to take effect.
+func Refresh(ctx context.Context, url string) error {
+	if url == "" {
+		url = publicationURL
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("mic: building refresh request for %v: %w", url, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("mic: downloading %v: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("mic: downloading %v: unexpected status %v", url, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("mic: reading response body from %v: %w", url, err)
+	}
+
+	ms, err := parseCSV(string(data))
+	if err != nil {
+		return fmt.Errorf("mic: refused refresh, downloaded CSV failed validation: %w", err)
+	}
+
+	if err := resolveZones(ms); err != nil {
+		return fmt.Errorf("mic: refused refresh, downloaded CSV failed validation: %w", err)
+	}
+
+	if err := os.WriteFile(datasetPath, data, 0o644); err != nil {
+		return fmt.Errorf("mic: writing %v: %w", datasetPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package mic
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// resolveZones sets tzname on every market from cityZone, failing if a city
+// in the embedded dataset has no entry in the table.
+func resolveZones(ms []*Market) error {
+	for _, m := range ms {
+		z, ok := cityZone[m.City]
+		if !ok {
+			return fmt.Errorf("city '%v' has no entry in cityZone", m.City)
+		}
+
+		m.tzname = z
+	}
+
+	return nil
+}
+
+//go:embed city_timezones.csv
+var cityTimezonesCSV string
+
+// cityZone maps a MIC's city (as it appears in the ISO 10383 CITY column) to
+// its IANA time zone identifier, parsed at init from city_timezones.csv.
+// That file is shared with generate_mics_python (its readCityTimezones
+// reads the same path via "../mic/city_timezones.csv") so the two packages
+// can no longer drift the way their former hand-duplicated Go map literals
+// did.
+var cityZone = parseCityZone(cityTimezonesCSV)
+
+func parseCityZone(data string) map[string]string {
+	zm := map[string]string{}
+
+	r := csv.NewReader(strings.NewReader(data))
+	r.Comma = '|'
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.ReuseRecord = false
+	r.TrimLeadingSpace = true
+
+	ln := 0
+
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		ln++
+		if err != nil {
+			panic("mic: embedded city_timezones.csv line " + fmt.Sprint(ln) + " is invalid: " + err.Error())
+		}
+
+		zm[record[0]] = record[1]
+	}
+
+	return zm
+}
+
+// Zone returns the IANA time zone identifier for m's city, e.g.
+// "Europe/Amsterdam". It is empty only if the embedded dataset contains a
+// city that is missing from cityZone, which init validates never happens.
+func (m *Market) Zone() string {
+	return m.tzname
+}
+
+// TimeZones returns every distinct IANA zone identifier present in the
+// dataset, sorted.
+func TimeZones() []string {
+	zones := make([]string, 0, len(byZone))
+	for z := range byZone {
+		zones = append(zones, z)
+	}
+
+	sort.Strings(zones)
+
+	return zones
+}
+
+// ByTimeZone returns every market whose city resolves to the given IANA zone
+// identifier, e.g. ByTimeZone("Europe/Amsterdam").
+func ByTimeZone(zone string) []*Market {
+	return append([]*Market(nil), byZone[zone]...)
+}
+
+func validateZones() error {
+	loaded := map[string]*time.Location{}
+
+	for city, z := range cityZone {
+		if _, ok := loaded[z]; ok {
+			continue
+		}
+
+		loc, err := time.LoadLocation(z)
+		if err != nil {
+			return &ZoneError{City: city, Zone: z, Err: err}
+		}
+
+		loaded[z] = loc
+	}
+
+	return nil
+}
+
+// ZoneError is returned by init (via validateZones) when a city in the
+// embedded dataset maps to an IANA zone Go cannot load.
+type ZoneError struct {
+	City string
+	Zone string
+	Err  error
+}
+
+func (e *ZoneError) Error() string {
+	return "mic: city '" + e.City + "' maps to unloadable zone '" + e.Zone + "': " + e.Err.Error()
+}
+
+func (e *ZoneError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,8 @@
+// Package micgo provides ISO 10383 Market Identifier Codes as one
+// package-level MIC struct literal per code, plus a ByMIC lookup. It is
+// entirely generated by generate_mics_python (-target go) into mics.go;
+// this file only reserves the directory and records that fact, since
+// mics.go does not exist until that target has been run at least once.
+//
+//go:generate go run ../generate -target go
+package micgo
@@ -0,0 +1,9 @@
+package mics
+
+// data.go defines the packed tables mics.go reads (micBlob, countryCodes,
+// countryIndex, timeZones, tzIndex, opIndex). It is generated by
+// generate_mics_python (-target micspkg) and does not exist until that
+// target has been run at least once; this file only reserves the
+// directory and records that fact, mirroring micgo/doc.go.
+//
+//go:generate go run ../generate -target micspkg
@@ -0,0 +1,149 @@
+// Package mics provides a compact, allocation-light runtime lookup API over
+// the ISO 10383 Market Identifier Code dataset. Unlike package mic's
+// map[string]*Market index, every MIC here is decoded on demand from a
+// handful of packed tables in data.go: one fixed-width byte blob holding
+// every 4-character MIC code, plus parallel index slices for country, time
+// zone and operating-MIC lookups. data.go is generated by
+// generate_mics_python (-target micspkg); this file is hand-written and
+// holds the public API only.
+package mics
+
+import (
+	"sort"
+)
+
+// micCodeLen is the fixed width, in bytes, of every MIC code packed into
+// micBlob.
+const micCodeLen = 4
+
+// timeZone is one entry of the timeZones table in data.go: an IANA zone
+// name and its current standard/DST offsets, seconds east of UTC.
+type timeZone struct {
+	name                 string
+	stdOffset, dstOffset int
+}
+
+// MIC is a single ISO 10383 Market Identifier Code, decoded from the packed
+// tables at the moment it's looked up or iterated.
+type MIC struct {
+	Code         string
+	OperatingMIC string
+	CountryCode  string
+	Operational  bool
+	Timezone     string
+}
+
+func rowCount() int {
+	return len(micBlob) / micCodeLen
+}
+
+func codeAt(row int) string {
+	return micBlob[row*micCodeLen : row*micCodeLen+micCodeLen]
+}
+
+func micAt(row int) MIC {
+	return MIC{
+		Code:         codeAt(row),
+		OperatingMIC: codeAt(int(opIndex[row])),
+		CountryCode:  countryCodes[countryIndex[row]],
+		Operational:  int(opIndex[row]) == row,
+		Timezone:     timeZones[tzIndex[row]].name,
+	}
+}
+
+// rowOf returns the row of code in micBlob via binary search, since micBlob
+// is packed in sorted order.
+func rowOf(code string) (int, bool) {
+	n := rowCount()
+
+	row := sort.Search(n, func(i int) bool { return codeAt(i) >= code })
+	if row == n || codeAt(row) != code {
+		return 0, false
+	}
+
+	return row, true
+}
+
+// Lookup finds a MIC by its own code, e.g. Lookup("XNAS").
+func Lookup(code string) (MIC, bool) {
+	row, ok := rowOf(code)
+	if !ok {
+		return MIC{}, false
+	}
+
+	return micAt(row), true
+}
+
+// ByCountry returns every MIC registered under the given ISO 3166 alpha-2
+// country code, e.g. ByCountry("US").
+func ByCountry(iso3166 string) []MIC {
+	idx := -1
+
+	for i, c := range countryCodes {
+		if c == iso3166 {
+			idx = i
+
+			break
+		}
+	}
+
+	if idx < 0 {
+		return nil
+	}
+
+	var ms []MIC
+
+	for row, ci := range countryIndex {
+		if int(ci) == idx {
+			ms = append(ms, micAt(row))
+		}
+	}
+
+	return ms
+}
+
+// ByTimezone returns every MIC whose IANA zone currently resolves to the
+// given seconds-east-of-UTC offset, standard or daylight-saving alike.
+func ByTimezone(seconds int) []MIC {
+	var ms []MIC
+
+	for row, ti := range tzIndex {
+		z := timeZones[ti]
+		if z.stdOffset == seconds || z.dstOffset == seconds {
+			ms = append(ms, micAt(row))
+		}
+	}
+
+	return ms
+}
+
+// Segments returns every segment MIC belonging to op. op itself is not
+// included.
+func Segments(op MIC) []MIC {
+	opRow, ok := rowOf(op.Code)
+	if !ok {
+		return nil
+	}
+
+	var ms []MIC
+
+	for row, oi := range opIndex {
+		if int(oi) == opRow && row != opRow {
+			ms = append(ms, micAt(row))
+		}
+	}
+
+	return ms
+}
+
+// All returns every MIC in micBlob order (sorted by code).
+func All() []MIC {
+	n := rowCount()
+	ms := make([]MIC, n)
+
+	for row := 0; row < n; row++ {
+		ms[row] = micAt(row)
+	}
+
+	return ms
+}
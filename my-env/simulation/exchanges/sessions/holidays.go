@@ -0,0 +1,134 @@
+package sessions
+
+import (
+	"fmt"
+	"time"
+)
+
+// HolidayRule describes one recurring or relative holiday. Exactly one of
+// the recurrence shapes below applies, selected by Type.
+type HolidayRule struct {
+	Type string // "fixed", "nth-weekday", "easter-relative" or "lunar-new-year"
+	Name string
+
+	// "fixed": the holiday falls on Month/Day every year.
+	Month time.Month
+	Day   int
+
+	// "nth-weekday": the Nth occurrence of Weekday in Month (Nth may be
+	// negative, e.g. -1 for "last Friday of the month").
+	Weekday time.Weekday
+	Nth     int
+
+	// "easter-relative": Offset days from that year's Western Easter Sunday,
+	// e.g. -2 for Good Friday, 1 for Easter Monday.
+	// "lunar-new-year": Offset days from that year's Lunar New Year, as
+	// looked up in LunarNewYear.
+	Offset int
+}
+
+// HolidayOverride is an explicit one-off holiday date, layered on top of the
+// recurring rules - for ad-hoc closures that don't fit any rule.
+type HolidayOverride struct {
+	Date time.Time
+	Name string
+}
+
+var errUnknownHolidayRuleType = fmt.Errorf("unknown holiday rule type, want one of fixed, nth-weekday, easter-relative, lunar-new-year")
+
+// errNoLunarNewYear is returned when a lunar-new-year rule is evaluated for
+// a year absent from the LunarNewYear table.
+var errNoLunarNewYear = fmt.Errorf("no lunar new year date on file for this year")
+
+// Date resolves this rule to a single calendar date in the given year.
+func (r HolidayRule) Date(year int) (time.Time, error) {
+	switch r.Type {
+	case "fixed":
+		return time.Date(year, r.Month, r.Day, 0, 0, 0, 0, time.UTC), nil
+
+	case "nth-weekday":
+		return nthWeekdayOfMonth(year, r.Month, r.Weekday, r.Nth), nil
+
+	case "easter-relative":
+		return easterSunday(year).AddDate(0, 0, r.Offset), nil
+
+	case "lunar-new-year":
+		base, ok := LunarNewYear[year]
+		if !ok {
+			return time.Time{}, fmt.Errorf("%v: %w", year, errNoLunarNewYear)
+		}
+
+		return base.AddDate(0, 0, r.Offset), nil
+
+	default:
+		return time.Time{}, fmt.Errorf("%q: %w", r.Type, errUnknownHolidayRuleType)
+	}
+}
+
+// nthWeekdayOfMonth returns the nth occurrence of weekday in month/year. A
+// negative nth counts from the end of the month, e.g. -1 is the last
+// occurrence.
+func nthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, nth int) time.Time {
+	if nth > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		offset := (int(weekday) - int(first.Weekday()) + 7) % 7
+
+		return first.AddDate(0, 0, offset+7*(nth-1))
+	}
+
+	// Walk backward from the first of the following month.
+	next := time.Date(year, month+1, 1, 0, 0, 0, 0, time.UTC)
+	offset := (int(next.Weekday()) - int(weekday) + 7) % 7
+
+	if offset == 0 {
+		offset = 7
+	}
+
+	last := next.AddDate(0, 0, -offset)
+
+	return last.AddDate(0, 0, 7*(nth+1))
+}
+
+// easterSunday computes the Gregorian Easter Sunday date via the anonymous
+// Gregorian algorithm (Meeus/Jones/Butcher).
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// LunarNewYear gives the Gregorian date of Lunar New Year for each year
+// sessions.yaml's "lunar-new-year" rules may reference. Extend this table as
+// calendars are added for years further out; evaluating a year absent here
+// fails with errNoLunarNewYear rather than guessing.
+var LunarNewYear = map[int]time.Time{
+	2019: date(2019, time.February, 5),
+	2020: date(2020, time.January, 25),
+	2021: date(2021, time.February, 12),
+	2022: date(2022, time.February, 1),
+	2023: date(2023, time.January, 22),
+	2024: date(2024, time.February, 10),
+	2025: date(2025, time.January, 29),
+	2026: date(2026, time.February, 17),
+	2027: date(2027, time.February, 6),
+	2028: date(2028, time.January, 26),
+	2029: date(2029, time.February, 13),
+	2030: date(2030, time.February, 3),
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,87 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEasterSunday(t *testing.T) {
+	cases := map[int]string{
+		2023: "2023-04-09",
+		2024: "2024-03-31",
+		2025: "2025-04-20",
+	}
+
+	for year, want := range cases {
+		got := easterSunday(year).Format("2006-01-02")
+		if got != want {
+			t.Errorf("easterSunday(%v) = %v, want %v", year, got, want)
+		}
+	}
+}
+
+func TestHolidayRuleDateGoodFriday(t *testing.T) {
+	r := HolidayRule{Type: "easter-relative", Name: "Good Friday", Offset: -2}
+
+	got, err := r.Date(2024)
+	if err != nil {
+		t.Fatalf("Date: %v", err)
+	}
+
+	if want := "2024-03-29"; got.Format("2006-01-02") != want {
+		t.Errorf("Good Friday 2024 = %v, want %v", got.Format("2006-01-02"), want)
+	}
+}
+
+func TestNthWeekdayOfMonthThanksgiving(t *testing.T) {
+	// US Thanksgiving: 4th Thursday of November.
+	r := HolidayRule{Type: "nth-weekday", Name: "Thanksgiving", Month: time.November, Weekday: time.Thursday, Nth: 4}
+
+	got, err := r.Date(2023)
+	if err != nil {
+		t.Fatalf("Date: %v", err)
+	}
+
+	if want := "2023-11-23"; got.Format("2006-01-02") != want {
+		t.Errorf("Thanksgiving 2023 = %v, want %v", got.Format("2006-01-02"), want)
+	}
+}
+
+func TestNthWeekdayOfMonthLastOccurrence(t *testing.T) {
+	// US Memorial Day: last Monday of May.
+	r := HolidayRule{Type: "nth-weekday", Name: "Memorial Day", Month: time.May, Weekday: time.Monday, Nth: -1}
+
+	got, err := r.Date(2023)
+	if err != nil {
+		t.Fatalf("Date: %v", err)
+	}
+
+	if want := "2023-05-29"; got.Format("2006-01-02") != want {
+		t.Errorf("Memorial Day 2023 = %v, want %v", got.Format("2006-01-02"), want)
+	}
+}
+
+func TestHolidayRuleDateLunarNewYear(t *testing.T) {
+	r := HolidayRule{Type: "lunar-new-year", Name: "Lunar New Year"}
+
+	got, err := r.Date(2024)
+	if err != nil {
+		t.Fatalf("Date: %v", err)
+	}
+
+	if want := "2024-02-10"; got.Format("2006-01-02") != want {
+		t.Errorf("Lunar New Year 2024 = %v, want %v", got.Format("2006-01-02"), want)
+	}
+
+	if _, err := r.Date(1999); err == nil {
+		t.Error("Date(1999) = nil error, want errNoLunarNewYear (year absent from the table)")
+	}
+}
+
+func TestHolidayRuleDateUnknownType(t *testing.T) {
+	r := HolidayRule{Type: "bogus"}
+
+	if _, err := r.Date(2024); err == nil {
+		t.Error("Date with an unknown rule type = nil error, want errUnknownHolidayRuleType")
+	}
+}
@@ -0,0 +1,480 @@
+// Package sessions provides the trading-hours and holiday-calendar data
+// attached to each operating MIC: regular sessions (pre-open, continuous,
+// auction close, post), lunch breaks, half-days and holiday calendars. It is
+// read from sessions.yaml by the generate_mics_python tool, which validates
+// every operating MIC named there against the parsed ISO 10383 dataset and
+// uses it to emit Market.is_open/next_open/next_close/sessions_between in
+// mics.py. This package also exposes the same calendar directly to Go
+// callers, mirroring how package mic re-exposes the ISO 10383 dataset.
+package sessions
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// Session is one named trading window within a day, e.g. the continuous
+// session or a pre-open auction, expressed in minutes since local midnight.
+type Session struct {
+	Name  string
+	Open  int // minutes since midnight, local to the MIC's time zone
+	Close int
+}
+
+// Calendar is the full session and holiday schedule for one operating MIC.
+type Calendar struct {
+	OperatingMIC string
+	Sessions     []Session // regular sessions, e.g. a continuous session, or a morning/afternoon pair
+	Lunch        *Session  // gap carved out of whichever Sessions window contains it, or nil
+	HalfDays     map[string]int // "2006-01-02" -> override close, minutes since midnight
+	Rules        []HolidayRule
+	Overrides    []HolidayOverride
+}
+
+var errMissingSessions = errors.New("calendar has no sessions")
+
+// Load reads and validates sessions.yaml into a map of Calendar keyed by
+// operating MIC.
+func Load(filename string) (map[string]*Calendar, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("opening sessions: %w", err)
+	}
+
+	root, err := decodeYAML(data)
+	if err != nil {
+		return nil, fmt.Errorf("'%v': %w", filename, err)
+	}
+
+	calendars := make(map[string]*Calendar, len(root))
+
+	for mic, raw := range root {
+		node, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("'%v': %v: expected a mapping", filename, mic)
+		}
+
+		c, err := parseCalendar(mic, node)
+		if err != nil {
+			return nil, fmt.Errorf("'%v': %w", filename, err)
+		}
+
+		calendars[mic] = c
+	}
+
+	return calendars, nil
+}
+
+// ValidateOperatingMICs fails if sessions.yaml names an operating MIC that
+// does not appear in the parsed ISO 10383 dataset, so a typo or a retired
+// MIC fails code generation instead of silently producing a dead entry.
+func ValidateOperatingMICs(calendars map[string]*Calendar, knownOperatingMICs map[string]bool) error {
+	for mic := range calendars {
+		if !knownOperatingMICs[mic] {
+			return fmt.Errorf("sessions.yaml: %w: %v", errUnknownOperatingMIC, mic)
+		}
+	}
+
+	return nil
+}
+
+var errUnknownOperatingMIC = errors.New("operating MIC not found in the ISO 10383 dataset")
+
+func parseCalendar(mic string, node map[string]interface{}) (*Calendar, error) {
+	c := &Calendar{OperatingMIC: mic, HalfDays: map[string]int{}}
+
+	rawSessions, _ := node["sessions"].([]interface{})
+	for _, rs := range rawSessions {
+		sm, ok := rs.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v: sessions: expected a mapping per item", mic)
+		}
+
+		s, err := parseSession(sm)
+		if err != nil {
+			return nil, fmt.Errorf("%v: sessions: %w", mic, err)
+		}
+
+		c.Sessions = append(c.Sessions, s)
+	}
+
+	if len(c.Sessions) == 0 {
+		return nil, fmt.Errorf("%v: %w", mic, errMissingSessions)
+	}
+
+	if rawLunch, ok := node["lunch"].(map[string]interface{}); ok {
+		lunch, err := parseSession(rawLunch)
+		if err != nil {
+			return nil, fmt.Errorf("%v: lunch: %w", mic, err)
+		}
+
+		c.Lunch = &lunch
+	}
+
+	rawHalfDays, _ := node["half_days"].([]interface{})
+	for _, rh := range rawHalfDays {
+		hm, ok := rh.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v: half_days: expected a mapping per item", mic)
+		}
+
+		d, _ := hm["date"].(string)
+
+		close, err := parseDayTime(fmt.Sprint(hm["close"]))
+		if err != nil {
+			return nil, fmt.Errorf("%v: half_days: %v: %w", mic, d, err)
+		}
+
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return nil, fmt.Errorf("%v: half_days: %w", mic, err)
+		}
+
+		c.HalfDays[d] = close
+	}
+
+	holidays, _ := node["holidays"].(map[string]interface{})
+
+	rawRules, _ := holidays["rules"].([]interface{})
+	for _, rr := range rawRules {
+		rm, ok := rr.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v: holidays.rules: expected a mapping per item", mic)
+		}
+
+		rule, err := parseHolidayRule(rm)
+		if err != nil {
+			return nil, fmt.Errorf("%v: holidays.rules: %w", mic, err)
+		}
+
+		c.Rules = append(c.Rules, rule)
+	}
+
+	rawOverrides, _ := holidays["overrides"].([]interface{})
+	for _, ro := range rawOverrides {
+		om, ok := ro.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v: holidays.overrides: expected a mapping per item", mic)
+		}
+
+		d, _ := om["date"].(string)
+
+		t, err := time.Parse("2006-01-02", d)
+		if err != nil {
+			return nil, fmt.Errorf("%v: holidays.overrides: %w", mic, err)
+		}
+
+		c.Overrides = append(c.Overrides, HolidayOverride{Date: t, Name: fmt.Sprint(om["name"])})
+	}
+
+	return c, nil
+}
+
+func parseSession(m map[string]interface{}) (Session, error) {
+	name, _ := m["name"].(string)
+
+	open, err := parseDayTime(fmt.Sprint(m["open"]))
+	if err != nil {
+		return Session{}, err
+	}
+
+	close, err := parseDayTime(fmt.Sprint(m["close"]))
+	if err != nil {
+		return Session{}, err
+	}
+
+	return Session{Name: name, Open: open, Close: close}, nil
+}
+
+// parseDayTime parses an "HH:MM" string into minutes since midnight.
+func parseDayTime(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("'%v': invalid HH:MM time: %w", s, err)
+	}
+
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+var errUnknownWeekday = errors.New("unknown weekday name")
+
+func parseHolidayRule(m map[string]interface{}) (HolidayRule, error) {
+	name, _ := m["name"].(string)
+	ruleType, _ := m["type"].(string)
+
+	r := HolidayRule{Type: ruleType, Name: name}
+
+	switch ruleType {
+	case "fixed":
+		r.Month = time.Month(atoi(fmt.Sprint(m["month"])))
+		r.Day = atoi(fmt.Sprint(m["day"]))
+
+	case "nth-weekday":
+		r.Month = time.Month(atoi(fmt.Sprint(m["month"])))
+		r.Nth = atoi(fmt.Sprint(m["nth"]))
+
+		wd, ok := weekdays[fmt.Sprint(m["weekday"])]
+		if !ok {
+			return HolidayRule{}, fmt.Errorf("%q: %w", m["weekday"], errUnknownWeekday)
+		}
+
+		r.Weekday = wd
+
+	case "easter-relative", "lunar-new-year":
+		r.Offset = atoi(fmt.Sprint(m["offset"]))
+
+	default:
+		return HolidayRule{}, fmt.Errorf("%q: %w", ruleType, errUnknownHolidayRuleType)
+	}
+
+	return r, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func atoi(s string) int {
+	n := 0
+
+	neg := false
+	if len(s) > 0 && s[0] == '-' {
+		neg = true
+		s = s[1:]
+	}
+
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			break
+		}
+
+		n = n*10 + int(r-'0')
+	}
+
+	if neg {
+		n = -n
+	}
+
+	return n
+}
+
+// Holidays returns every holiday date this calendar observes in year, from
+// both its recurring Rules and its explicit Overrides, sorted and deduplicated.
+func (c *Calendar) Holidays(year int) ([]time.Time, error) {
+	seen := map[string]bool{}
+
+	dates := []time.Time{}
+
+	for _, r := range c.Rules {
+		d, err := r.Date(year)
+		if err != nil {
+			return nil, fmt.Errorf("%v: %w", r.Name, err)
+		}
+
+		key := d.Format("2006-01-02")
+		if !seen[key] {
+			seen[key] = true
+
+			dates = append(dates, d)
+		}
+	}
+
+	for _, o := range c.Overrides {
+		if o.Date.Year() != year {
+			continue
+		}
+
+		key := o.Date.Format("2006-01-02")
+		if !seen[key] {
+			seen[key] = true
+
+			dates = append(dates, o.Date)
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+	return dates, nil
+}
+
+// isHoliday reports whether date (truncated to a calendar day) is a holiday.
+func (c *Calendar) isHoliday(date time.Time) (bool, error) {
+	holidays, err := c.Holidays(date.Year())
+	if err != nil {
+		return false, err
+	}
+
+	key := date.Format("2006-01-02")
+	for _, h := range holidays {
+		if h.Format("2006-01-02") == key {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// daySessions returns this calendar's sessions for the given local calendar
+// day, with any half-day override applied to the last session's close and
+// the lunch break, if any, carved out as a gap. Returns nil on a holiday.
+func (c *Calendar) daySessions(local time.Time) ([]Session, error) {
+	holiday, err := c.isHoliday(local)
+	if err != nil {
+		return nil, err
+	}
+
+	if holiday {
+		return nil, nil
+	}
+
+	sessions := make([]Session, len(c.Sessions))
+	copy(sessions, c.Sessions)
+
+	if close, ok := c.HalfDays[local.Format("2006-01-02")]; ok {
+		sessions[len(sessions)-1].Close = close
+	}
+
+	if c.Lunch == nil {
+		return sessions, nil
+	}
+
+	out := make([]Session, 0, len(sessions)+1)
+
+	for _, s := range sessions {
+		if c.Lunch.Open <= s.Open || c.Lunch.Close >= s.Close {
+			out = append(out, s)
+
+			continue
+		}
+
+		out = append(out, Session{Name: s.Name, Open: s.Open, Close: c.Lunch.Open})
+		out = append(out, Session{Name: s.Name, Open: c.Lunch.Close, Close: s.Close})
+	}
+
+	return out, nil
+}
+
+// IsOpen reports whether the market is trading at t, interpreted in loc.
+func (c *Calendar) IsOpen(loc *time.Location, t time.Time) (bool, error) {
+	local := t.In(loc)
+
+	sessions, err := c.daySessions(local)
+	if err != nil {
+		return false, err
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+
+	for _, s := range sessions {
+		if minute >= s.Open && minute < s.Close {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+const maxLookaheadDays = 14
+
+var errNoSessionFound = errors.New("no session found within the lookahead window")
+
+// NextOpen returns the next time, at or after after, that a session opens.
+func (c *Calendar) NextOpen(loc *time.Location, after time.Time) (time.Time, error) {
+	local := after.In(loc)
+
+	for day := 0; day <= maxLookaheadDays; day++ {
+		d := truncateToDay(local).AddDate(0, 0, day)
+
+		sessions, err := c.daySessions(d)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		for _, s := range sessions {
+			open := atMinute(d, s.Open)
+			if !open.Before(local) {
+				return open, nil
+			}
+		}
+	}
+
+	return time.Time{}, errNoSessionFound
+}
+
+// NextClose returns the next time, at or after after, that an open session
+// closes.
+func (c *Calendar) NextClose(loc *time.Location, after time.Time) (time.Time, error) {
+	local := after.In(loc)
+
+	for day := 0; day <= maxLookaheadDays; day++ {
+		d := truncateToDay(local).AddDate(0, 0, day)
+
+		sessions, err := c.daySessions(d)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		for _, s := range sessions {
+			close := atMinute(d, s.Close)
+			if close.After(local) {
+				return close, nil
+			}
+		}
+	}
+
+	return time.Time{}, errNoSessionFound
+}
+
+// Window is one concrete, dated occurrence of a Session, as returned by
+// SessionsBetween.
+type Window struct {
+	Name  string
+	Open  time.Time
+	Close time.Time
+}
+
+// SessionsBetween returns every session window that overlaps [a, b], both
+// interpreted in loc.
+func (c *Calendar) SessionsBetween(loc *time.Location, a, b time.Time) ([]Window, error) {
+	a, b = a.In(loc), b.In(loc)
+
+	var windows []Window
+
+	for d := truncateToDay(a); !d.After(b); d = d.AddDate(0, 0, 1) {
+		sessions, err := c.daySessions(d)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range sessions {
+			w := Window{Name: s.Name, Open: atMinute(d, s.Open), Close: atMinute(d, s.Close)}
+			if w.Close.After(a) && w.Open.Before(b) {
+				windows = append(windows, w)
+			}
+		}
+	}
+
+	return windows, nil
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// atMinute returns the wall-clock time on day's calendar date at minute
+// minutes past local midnight. It builds the result via time.Date rather
+// than adding minute as a fixed duration to day, so a session opens/closes
+// at the intended local clock time even on a day that has a DST transition
+// earlier in the morning - adding a duration instead would silently drift
+// the result by the transition's offset.
+func atMinute(day time.Time, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, minute, 0, 0, day.Location())
+}
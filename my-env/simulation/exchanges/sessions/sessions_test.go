@@ -0,0 +1,126 @@
+package sessions
+
+import (
+	"testing"
+	"time"
+)
+
+// nyseCalendar is a simplified XNYS-shaped calendar: one continuous session,
+// 09:30-16:00 local, closed on Thanksgiving and Good Friday.
+func nyseCalendar() *Calendar {
+	return &Calendar{
+		OperatingMIC: "XNYS",
+		Sessions:     []Session{{Name: "Continuous", Open: 9*60 + 30, Close: 16 * 60}},
+		Rules: []HolidayRule{
+			{Type: "nth-weekday", Name: "Thanksgiving", Month: time.November, Weekday: time.Thursday, Nth: 4},
+			{Type: "easter-relative", Name: "Good Friday", Offset: -2},
+		},
+	}
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("LoadLocation(%v): %v", name, err)
+	}
+
+	return loc
+}
+
+func TestCalendarIsOpen(t *testing.T) {
+	c := nyseCalendar()
+	loc := mustLoadLocation(t, "America/New_York")
+
+	cases := []struct {
+		name string
+		t    time.Time
+		want bool
+	}{
+		{"mid-session", time.Date(2023, time.November, 20, 12, 0, 0, 0, loc), true},
+		{"at the open", time.Date(2023, time.November, 20, 9, 30, 0, 0, loc), true},
+		{"one minute before the open", time.Date(2023, time.November, 20, 9, 29, 0, 0, loc), false},
+		{"at the close", time.Date(2023, time.November, 20, 16, 0, 0, 0, loc), false},
+		{"one minute before the close", time.Date(2023, time.November, 20, 15, 59, 0, 0, loc), true},
+		{"weekend", time.Date(2023, time.November, 18, 12, 0, 0, 0, loc), true}, // daySessions doesn't special-case weekends itself
+		{"Thanksgiving 2023", time.Date(2023, time.November, 23, 12, 0, 0, 0, loc), false},
+		{"Good Friday 2024", time.Date(2024, time.March, 29, 12, 0, 0, 0, loc), false},
+		{"day after Good Friday 2024", time.Date(2024, time.March, 30, 12, 0, 0, 0, loc), true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := c.IsOpen(loc, tc.t)
+			if err != nil {
+				t.Fatalf("IsOpen: %v", err)
+			}
+
+			if got != tc.want {
+				t.Errorf("IsOpen(%v) = %v, want %v", tc.t, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalendarNextOpenSkipsHoliday(t *testing.T) {
+	c := nyseCalendar()
+	loc := mustLoadLocation(t, "America/New_York")
+
+	// Thanksgiving 2023 is Nov 23; asking from the close the day before should
+	// skip the holiday entirely and land on Nov 24's open.
+	after := time.Date(2023, time.November, 22, 17, 0, 0, 0, loc)
+
+	got, err := c.NextOpen(loc, after)
+	if err != nil {
+		t.Fatalf("NextOpen: %v", err)
+	}
+
+	want := time.Date(2023, time.November, 24, 9, 30, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("NextOpen(%v) = %v, want %v", after, got, want)
+	}
+}
+
+func TestCalendarNextCloseAcrossDSTSpringForward(t *testing.T) {
+	c := nyseCalendar()
+	loc := mustLoadLocation(t, "America/New_York")
+
+	// 2024-03-10 is the US spring-forward DST transition; the session's
+	// 09:30-16:00 local window should be unaffected since it's entirely
+	// after 03:00 local.
+	after := time.Date(2024, time.March, 10, 10, 0, 0, 0, loc)
+
+	got, err := c.NextClose(loc, after)
+	if err != nil {
+		t.Fatalf("NextClose: %v", err)
+	}
+
+	want := time.Date(2024, time.March, 10, 16, 0, 0, 0, loc)
+	if !got.Equal(want) {
+		t.Errorf("NextClose(%v) = %v, want %v", after, got, want)
+	}
+}
+
+func TestCalendarSessionsBetween(t *testing.T) {
+	c := nyseCalendar()
+	loc := mustLoadLocation(t, "America/New_York")
+
+	// Nov 22 (open) through Nov 24 (open, since Nov 23 is Thanksgiving):
+	// exactly two session windows should overlap.
+	a := time.Date(2023, time.November, 22, 0, 0, 0, 0, loc)
+	b := time.Date(2023, time.November, 24, 23, 59, 0, 0, loc)
+
+	got, err := c.SessionsBetween(loc, a, b)
+	if err != nil {
+		t.Fatalf("SessionsBetween: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("SessionsBetween returned %v windows, want 2 (Thanksgiving should produce no window): %+v", len(got), got)
+	}
+
+	if got[0].Open.Day() != 22 || got[1].Open.Day() != 24 {
+		t.Errorf("SessionsBetween days = %v, %v, want 22, 24", got[0].Open.Day(), got[1].Open.Day())
+	}
+}
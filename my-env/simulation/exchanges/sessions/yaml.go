@@ -0,0 +1,212 @@
+package sessions
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// decodeYAML parses the constrained YAML subset sessions.yaml uses: 2-space
+// indentation, `key: value` mappings, `key:` followed by a nested mapping or
+// a `- ` sequence, and scalar strings (bare, or quoted when they contain a
+// colon). It is not a general-purpose YAML parser: no anchors, flow style,
+// multiline scalars, or tab indentation.
+func decodeYAML(data []byte) (map[string]interface{}, error) {
+	lines := splitYAMLLines(data)
+
+	root, _, err := decodeYAMLMapping(lines, 0, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+type yamlLine struct {
+	indent int
+	text   string // trimmed of indentation and comments
+	lineNo int
+}
+
+func splitYAMLLines(data []byte) []yamlLine {
+	lines := []yamlLine{}
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := raw
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		line = strings.TrimRight(line, " \t\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := 0
+		for indent < len(line) && line[indent] == ' ' {
+			indent++
+		}
+
+		lines = append(lines, yamlLine{indent: indent, text: line[indent:], lineNo: i + 1})
+	}
+
+	return lines
+}
+
+// decodeYAMLMapping decodes the run of lines at exactly the given indent,
+// starting at index i, until a line with a lesser indent ends the mapping.
+// It returns the parsed mapping and the index of the first line not consumed.
+func decodeYAMLMapping(lines []yamlLine, i, indent int) (map[string]interface{}, int, error) {
+	m := map[string]interface{}{}
+
+	for i < len(lines) {
+		line := lines[i]
+		if line.indent < indent {
+			break
+		}
+
+		if line.indent > indent {
+			return nil, i, fmt.Errorf("line %v: unexpected indent", line.lineNo)
+		}
+
+		if strings.HasPrefix(line.text, "- ") || line.text == "-" {
+			return nil, i, fmt.Errorf("line %v: expected a mapping key, found a sequence item", line.lineNo)
+		}
+
+		key, value, hasValue := splitYAMLKeyValue(line.text)
+
+		if hasValue {
+			m[key] = unquoteYAMLScalar(value)
+			i++
+
+			continue
+		}
+
+		// `key:` with nothing after it: the value is a nested block on the
+		// following, more-indented lines - either another mapping or a
+		// sequence.
+		i++
+
+		if i >= len(lines) || lines[i].indent <= indent {
+			m[key] = nil
+
+			continue
+		}
+
+		childIndent := lines[i].indent
+
+		if strings.HasPrefix(lines[i].text, "- ") || lines[i].text == "-" {
+			seq, next, err := decodeYAMLSequence(lines, i, childIndent)
+			if err != nil {
+				return nil, i, err
+			}
+
+			m[key] = seq
+			i = next
+
+			continue
+		}
+
+		child, next, err := decodeYAMLMapping(lines, i, childIndent)
+		if err != nil {
+			return nil, i, err
+		}
+
+		m[key] = child
+		i = next
+	}
+
+	return m, i, nil
+}
+
+func decodeYAMLSequence(lines []yamlLine, i, indent int) ([]interface{}, int, error) {
+	seq := []interface{}{}
+
+	for i < len(lines) {
+		line := lines[i]
+		if line.indent != indent {
+			break
+		}
+
+		if !strings.HasPrefix(line.text, "- ") && line.text != "-" {
+			break
+		}
+
+		rest := strings.TrimPrefix(line.text, "-")
+		rest = strings.TrimPrefix(rest, " ")
+
+		if rest == "" {
+			// `-` alone on its line: item is a nested mapping indented further.
+			i++
+
+			if i >= len(lines) || lines[i].indent <= indent {
+				seq = append(seq, nil)
+
+				continue
+			}
+
+			child, next, err := decodeYAMLMapping(lines, i, lines[i].indent)
+			if err != nil {
+				return nil, i, err
+			}
+
+			seq = append(seq, child)
+			i = next
+
+			continue
+		}
+
+		if key, value, hasValue := splitYAMLKeyValue(rest); hasValue || strings.Contains(rest, ":") {
+			// `- key: value`: treat the rest of this line, plus any more
+			// deeply indented lines that follow, as one inline mapping.
+			inline := map[string]interface{}{key: unquoteYAMLScalar(value)}
+
+			i++
+
+			for i < len(lines) && lines[i].indent > indent {
+				k, v, ok := splitYAMLKeyValue(lines[i].text)
+				if !ok {
+					return nil, i, fmt.Errorf("line %v: expected 'key: value' inside sequence item", lines[i].lineNo)
+				}
+
+				inline[k] = unquoteYAMLScalar(v)
+				i++
+			}
+
+			seq = append(seq, inline)
+
+			continue
+		}
+
+		seq = append(seq, unquoteYAMLScalar(rest))
+		i++
+	}
+
+	return seq, i, nil
+}
+
+func splitYAMLKeyValue(text string) (key, value string, hasValue bool) {
+	idx := strings.Index(text, ":")
+	if idx < 0 {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(text[:idx])
+	rest := strings.TrimSpace(text[idx+1:])
+
+	if rest == "" {
+		return key, "", false
+	}
+
+	return key, rest, true
+}
+
+func unquoteYAMLScalar(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if unquoted, err := strconv.Unquote(s); err == nil {
+			return unquoted
+		}
+	}
+
+	return s
+}